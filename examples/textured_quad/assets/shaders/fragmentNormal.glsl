@@ -0,0 +1,9 @@
+#version 460
+layout (location = 0) out vec4 frag_color;
+
+in vec3 out_normal;
+
+void main() {
+    // edit and press F5 to see the shader reload
+    frag_color = vec4(normalize(out_normal) * 0.5 + 0.5, 1.0);
+}
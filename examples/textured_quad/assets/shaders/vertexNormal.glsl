@@ -0,0 +1,13 @@
+#version 460
+layout (location = 0) in vec3 position;
+layout (location = 1) in vec3 normal;
+
+out vec3 out_normal;
+
+uniform mat4 model;
+uniform mat4 vp;
+
+void main() {
+    gl_Position = vp * model * vec4(position, 1.0);
+    out_normal = mat3(model) * normal;
+}
@@ -5,10 +5,9 @@ import (
 
 	"github.com/devodev/opengl-experiment/internal/engine/application"
 	"github.com/devodev/opengl-experiment/internal/engine/renderer"
+	"github.com/devodev/opengl-experiment/internal/engine/window"
 	"github.com/devodev/opengl-experiment/internal/opengl"
 
-	"github.com/go-gl/gl/v4.6-core/gl"
-	"github.com/go-gl/glfw/v3.3/glfw"
 	"github.com/go-gl/mathgl/mgl32"
 )
 
@@ -17,6 +16,10 @@ type SquareTextureLayer struct {
 	texture1         *opengl.Texture
 	texture2         *opengl.Texture
 	texture3         *opengl.Texture
+	cube             *renderer.Mesh
+	cubeProgram      *opengl.ShaderProgram
+	cubeAngle        float32
+	camera           *renderer.CameraSwitchable
 	cameraController *renderer.CameraController
 }
 
@@ -35,14 +38,30 @@ func NewSquareTextureLayer(app *application.Application) (*SquareTextureLayer, e
 		return nil, fmt.Errorf("error creating texture3: %s", err)
 	}
 
+	cube, err := renderer.NewCube()
+	if err != nil {
+		return nil, fmt.Errorf("error creating cube: %s", err)
+	}
+	// loaded from files so that it can be reloaded with ActionReloadShaders
+	cubeProgram, err := app.GetResourceCache().LoadShaderProgram(
+		"assets/shaders/vertexNormal.glsl",
+		"assets/shaders/fragmentNormal.glsl",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cube shader program: %s", err)
+	}
+
 	width, height := app.GetWindow().GetGLFWWindow().GetSize()
-	// cameraController := renderer.NewCameraController(renderer.NewCameraPerspective(width, height))
-	cameraController := renderer.NewCameraController(renderer.NewCameraOrthographic(width, height))
+	camera := renderer.NewCameraSwitchable(width, height, renderer.ProjectionOrthographic)
+	cameraController := renderer.NewCameraController(camera)
 
 	component := &SquareTextureLayer{
 		texture1:         texture1,
 		texture2:         texture2,
 		texture3:         texture3,
+		cube:             cube,
+		cubeProgram:      cubeProgram,
+		camera:           camera,
 		cameraController: cameraController,
 	}
 	return component, nil
@@ -56,6 +75,7 @@ func (c *SquareTextureLayer) OnInit(app *application.Application) {
 func (c *SquareTextureLayer) OnUpdate(app *application.Application, deltaTime float64) {
 	c.processInput(app)
 	c.cameraController.OnUpdate(app.GetWindow(), deltaTime)
+	c.cubeAngle += float32(deltaTime)
 }
 
 // OnRender .
@@ -71,29 +91,68 @@ func (c *SquareTextureLayer) OnRender(app *application.Application, deltaTime fl
 	app.GetRenderer().DrawTexturedQuad(pos3, c.texture3)
 	app.GetRenderer().DrawTexturedQuad(pos4, c.texture1)
 	app.GetRenderer().End()
+
+	// uniforms are set every frame, they are lost when the program is reloaded
+	vp := c.cameraController.GetViewProjectionMatrix()
+	c.cubeProgram.Bind()
+	c.cubeProgram.SetUniformMatrix4fv("vp", 1, false, &vp[0])
+	cubeTransform := mgl32.Translate3D(0.75, 0.5, 0).
+		Mul4(mgl32.HomogRotate3D(c.cubeAngle, mgl32.Vec3{1, 1, 0}.Normalize())).
+		Mul4(mgl32.Scale3D(0.25, 0.25, 0.25))
+	app.GetRenderer().DrawMeshWithTransform(c.cube, c.cubeProgram, cubeTransform)
 }
 
 func (c *SquareTextureLayer) processInput(app *application.Application) {
-	glfwWindow := app.GetWindow().GetGLFWWindow()
+	w := app.GetWindow()
 
 	// we lost focus, dont process synthetic events
-	if glfwWindow.GetAttrib(glfw.Focused) == glfw.False {
+	if !w.IsFocused() {
 		return
 	}
 
-	// close window
-	if glfwWindow.GetKey(glfw.KeyEscape) != glfw.Release {
-		app.RequestClose()
-		return
+	// release the cursor, or close window
+	if w.IsActionPressed(window.ActionQuit) {
+		if w.GetCursorMode() != window.CursorDisabled {
+			app.RequestClose()
+			return
+		}
+		w.SetCursorMode(window.CursorNormal)
+	}
+	// capture the cursor for mouse-look
+	if w.IsActionPressed(window.ActionCaptureCursor) {
+		w.SetCursorMode(window.CursorDisabled)
 	}
 	// toggle wireframes
-	if glfwWindow.GetKey(glfw.KeySpace) == glfw.Press {
-		var currentPolygonMode int32
-		gl.GetIntegerv(gl.POLYGON_MODE, &currentPolygonMode)
-		if currentPolygonMode == gl.LINE {
-			gl.PolygonMode(gl.FRONT_AND_BACK, gl.FILL)
+	if w.IsActionPressed(window.ActionToggleWireframe) {
+		app.GetRenderer().SetWireframe(!app.GetRenderer().IsWireframe())
+	}
+	// toggle vsync
+	if w.IsActionPressed(window.ActionToggleVSync) {
+		w.SetVSync(!w.IsVSync())
+	}
+	// toggle face culling
+	if w.IsActionPressed(window.ActionToggleCulling) {
+		app.GetRenderer().SetFaceCulling(!app.GetRenderer().IsFaceCulling(), true, true)
+	}
+	// toggle perspective/orthographic projection
+	if w.IsActionPressed(window.ActionToggleProjection) {
+		if c.camera.GetProjectionMode() == renderer.ProjectionOrthographic {
+			c.camera.SetProjectionMode(renderer.ProjectionPerspective)
+		} else {
+			c.camera.SetProjectionMode(renderer.ProjectionOrthographic)
+		}
+	}
+	// reload the shader programs created from files
+	if w.IsActionPressed(window.ActionReloadShaders) {
+		// failures are reported by the watcher
+		app.GetShaderWatcher().ReloadAll()
+	}
+	// toggle fullscreen
+	if w.IsActionPressed(window.ActionToggleFullscreen) {
+		if w.GetMode() == window.ModeWindowed {
+			w.SetMode(window.ModeBorderlessFullscreen)
 		} else {
-			gl.PolygonMode(gl.FRONT_AND_BACK, gl.LINE)
+			w.SetMode(window.ModeWindowed)
 		}
 	}
 }
@@ -3,12 +3,13 @@ package application
 import (
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/devodev/opengl-experiment/internal/engine"
 	"github.com/devodev/opengl-experiment/internal/engine/renderer"
 	"github.com/devodev/opengl-experiment/internal/engine/window"
+	"github.com/devodev/opengl-experiment/internal/opengl"
 
-	"github.com/go-gl/gl/v4.6-core/gl"
 	"github.com/go-gl/glfw/v3.3/glfw"
 )
 
@@ -17,6 +18,15 @@ var (
 	ErrAlreadyClosed = errors.New("application already closed")
 )
 
+var (
+	// frames taking longer than this only advance the simulation
+	// by this amount, so that a slow frame does not trigger more
+	// updates, which would make the next frame even slower
+	maxFixedTimestepAccumulator = 0.25
+	// events are processed at 10 Hz while throttled
+	throttledEventsTimeout = 0.1
+)
+
 // Application needs to be used exclusively
 // on the main thread.
 //
@@ -24,12 +34,23 @@ var (
 // from the main function before initiliazing
 // an application.
 type Application struct {
-	running bool
+	running        bool
+	closed         bool
+	fpsInTitle     bool
+	throttleOnBlur bool
 
-	window       *window.Window
-	renderer     *renderer.Renderer
-	logger       *engine.SimpleLogger
-	frameCounter *FrameCounter
+	shutdownOnce sync.Once
+
+	// fixed timestep updates, disabled when fixedTimestep is 0
+	fixedTimestep float64
+	accumulator   float64
+
+	window        *window.Window
+	renderer      *renderer.Renderer
+	logger        *engine.SimpleLogger
+	frameCounter  *FrameCounter
+	shaderWatcher *opengl.ShaderWatcher
+	resourceCache *opengl.ResourceCache
 
 	layers []Layer
 }
@@ -45,10 +66,12 @@ func New(options ...Option) (*Application, error) {
 		return nil, err
 	}
 	app := &Application{
-		window:       window,
-		renderer:     renderer,
-		logger:       engine.NewLogger(),
-		frameCounter: NewFrameCounter(),
+		window:        window,
+		renderer:      renderer,
+		logger:        engine.NewLogger(),
+		frameCounter:  NewFrameCounter(),
+		shaderWatcher: opengl.NewShaderWatcher(),
+		resourceCache: opengl.NewResourceCache(),
 	}
 	for _, option := range options {
 		if err := option(app); err != nil {
@@ -74,29 +97,86 @@ func (a *Application) Run() error {
 
 	// main loop
 	for a.running {
-		a.frameCounter.OnUpdate(glfw.GetTime())
+		if a.frameCounter.OnUpdate(glfw.GetTime()) && a.fpsInTitle {
+			a.window.SetTitle(fmt.Sprintf("%s - %.1f FPS", a.window.GetTitle(), a.frameCounter.FPS()))
+		}
 		deltaTime := a.frameCounter.GetDelta()
+		if a.throttleOnBlur && !a.window.IsFocused() {
+			a.onThrottledUpdate()
+			continue
+		}
+		renderer.ResetStats()
 
-		a.renderer.Clear()
-		for _, layer := range a.layers {
-			layer.OnUpdate(a, deltaTime)
-			layer.OnRender(a, deltaTime)
+		a.shaderWatcher.Check()
+
+		if a.fixedTimestep > 0 {
+			a.runFixedTimestep(deltaTime)
+		} else {
+			a.renderer.Clear()
+			for _, layer := range a.layers {
+				layer.OnUpdate(a, deltaTime)
+				layer.OnRender(a, deltaTime)
+			}
 		}
+		a.renderer.FlushDebugShapes()
 		a.onUpdate()
 	}
 	return nil
 }
 
-// Close .
+// runFixedTimestep calls OnUpdate with the fixed timestep as many
+// times as needed to catch up with the elapsed time, then OnRender
+// once. The remaining time is exposed as GetInterpolationAlpha.
+func (a *Application) runFixedTimestep(deltaTime float64) {
+	a.accumulator += deltaTime
+	if a.accumulator > maxFixedTimestepAccumulator {
+		a.accumulator = maxFixedTimestepAccumulator
+	}
+	for a.accumulator >= a.fixedTimestep {
+		for _, layer := range a.layers {
+			layer.OnUpdate(a, a.fixedTimestep)
+		}
+		a.accumulator -= a.fixedTimestep
+	}
+
+	a.renderer.Clear()
+	for _, layer := range a.layers {
+		layer.OnRender(a, deltaTime)
+	}
+}
+
+// GetInterpolationAlpha returns how far, in [0, 1), the current frame
+// is between the last two fixed timestep updates, used to
+// interpolate state when rendering. It is 0 without fixed timestep.
+func (a *Application) GetInterpolationAlpha() float64 {
+	if a.fixedTimestep <= 0 {
+		return 0
+	}
+	return a.accumulator / a.fixedTimestep
+}
+
+// Close stops the main loop and shuts the application down.
 func (a *Application) Close() error {
-	if !a.running {
+	if a.closed {
 		return ErrAlreadyClosed
 	}
 	a.RequestClose()
-	if err := a.window.Close(); err != nil {
-		return err
-	}
-	return nil
+	return a.Shutdown()
+}
+
+// Shutdown deletes the OpenGL objects that were not deleted yet,
+// then destroys the window and terminates GLFW. Only the first
+// call has an effect, it is safe to call it more than once.
+func (a *Application) Shutdown() error {
+	var err error
+	a.shutdownOnce.Do(func() {
+		a.closed = true
+		if count := opengl.DeleteAll(); count > 0 {
+			a.logger.Printf("deleted %d OpenGL objects left at shutdown", count)
+		}
+		err = a.window.Close()
+	})
+	return err
 }
 
 // RequestClose .
@@ -118,7 +198,13 @@ func (a *Application) init() error {
 	if err := a.renderer.Init(); err != nil {
 		return fmt.Errorf("error initializing renderer: %v", err)
 	}
-	a.logger.Printf("OpenGL version: %s", gl.GoStr(gl.GetString(gl.VERSION)))
+	info := opengl.GetGLInfo()
+	a.logger.Printf("OpenGL version: %s", info.Version)
+	a.logger.Printf("OpenGL vendor: %s, renderer: %s", info.Vendor, info.Renderer)
+	a.logger.Printf("GLSL version: %s, %d extensions", info.GLSLVersion, len(info.Extensions))
+
+	a.renderer.SetViewport(a.window.GetFramebufferSize())
+	a.renderer.SetMultisample(a.window.GetSamples() > 0)
 	return nil
 }
 
@@ -127,16 +213,61 @@ func (a *Application) GetWindow() *window.Window {
 	return a.window
 }
 
+// GetFPS .
+func (a *Application) GetFPS() float64 {
+	return a.frameCounter.FPS()
+}
+
+// GetDeltaTime returns the time in seconds
+// elapsed since the previous frame.
+func (a *Application) GetDeltaTime() float64 {
+	return a.frameCounter.GetDelta()
+}
+
+// GetShaderWatcher returns the watcher checked once per frame,
+// hot-reloading the shader programs created from files.
+func (a *Application) GetShaderWatcher() *opengl.ShaderWatcher {
+	return a.shaderWatcher
+}
+
+// GetResourceCache returns the cache used to share
+// textures and shader programs loaded from files.
+func (a *Application) GetResourceCache() *opengl.ResourceCache {
+	return a.resourceCache
+}
+
 // GetRenderer .
 func (a *Application) GetRenderer() *renderer.Renderer {
 	return a.renderer
 }
 
+// onThrottledUpdate skips updating and rendering, only
+// processing events until the window regains focus.
+func (a *Application) onThrottledUpdate() {
+	if a.window.ShouldClose() {
+		a.RequestClose()
+		return
+	}
+	a.window.WaitEvents(throttledEventsTimeout)
+}
+
+// SetThrottleOnBlur stops updating and rendering the layers while
+// the window is not focused, events are still processed at a low
+// rate so that it can regain focus or be closed.
+func (a *Application) SetThrottleOnBlur(enabled bool) {
+	a.throttleOnBlur = enabled
+}
+
+// IsThrottleOnBlur .
+func (a *Application) IsThrottleOnBlur() bool {
+	return a.throttleOnBlur
+}
+
 func (a *Application) onUpdate() {
 	if a.window.ShouldClose() {
 		a.RequestClose()
 		return
 	}
-	glfw.PollEvents()
-	a.window.GetGLFWWindow().SwapBuffers()
+	a.window.PollEvents()
+	a.window.SwapBuffers()
 }
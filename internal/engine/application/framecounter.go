@@ -6,6 +6,9 @@ import (
 
 var (
 	defaultPrintDeltaSeconds = 1.0
+	// used when no time elapsed since the last update,
+	// typically on the first frame
+	defaultDeltaTime = 1.0 / 60.0
 )
 
 // FrameCounter .
@@ -16,6 +19,7 @@ type FrameCounter struct {
 	lastTime    float64
 	fpsLastTime float64
 	nbFrames    int
+	fps         float64
 }
 
 // NewFrameCounter .
@@ -31,17 +35,32 @@ func (f *FrameCounter) Init(currentTime float64) {
 	f.fpsLastTime = currentTime
 }
 
-// OnUpdate .
-func (f *FrameCounter) OnUpdate(currentTime float64) {
+// OnUpdate returns true when the FPS has been
+// recalculated, at most once per print delta.
+func (f *FrameCounter) OnUpdate(currentTime float64) bool {
 	f.deltaTime = currentTime - f.lastTime
 	f.lastTime = currentTime
+	if f.deltaTime <= 0 {
+		f.deltaTime = defaultDeltaTime
+	}
 
 	f.nbFrames++
-	if currentTime-f.fpsLastTime >= f.printDeltaSeconds {
-		fmt.Printf("%f ms/frame\n", (f.printDeltaSeconds*1000)/float64(f.nbFrames))
+	// divide by the real elapsed time, which is
+	// longer than the print delta after a stall
+	if elapsed := currentTime - f.fpsLastTime; elapsed >= f.printDeltaSeconds {
+		fmt.Printf("%f ms/frame\n", (elapsed*1000)/float64(f.nbFrames))
+		f.fps = float64(f.nbFrames) / elapsed
 		f.nbFrames = 0
-		f.fpsLastTime += 1.0
+		f.fpsLastTime = currentTime
+		return true
 	}
+	return false
+}
+
+// FPS returns the frame rate measured over
+// the last print delta, or longer after a stall.
+func (f *FrameCounter) FPS() float64 {
+	return f.fps
 }
 
 // GetDelta .
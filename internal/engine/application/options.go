@@ -1,7 +1,10 @@
 package application
 
 import (
+	"fmt"
+
 	"github.com/devodev/opengl-experiment/internal/engine"
+	"github.com/devodev/opengl-experiment/internal/engine/renderer"
 	"github.com/devodev/opengl-experiment/internal/engine/window"
 )
 
@@ -23,3 +26,44 @@ func WithWindowOption(window *window.Window) Option {
 		return nil
 	}
 }
+
+// WithRendererOption .
+func WithRendererOption(renderer *renderer.Renderer) Option {
+	return func(a *Application) error {
+		a.renderer = renderer
+		return nil
+	}
+}
+
+// WithFPSInTitleOption appends the frame rate
+// to the window title, updated once per second.
+func WithFPSInTitleOption(enabled bool) Option {
+	return func(a *Application) error {
+		a.fpsInTitle = enabled
+		return nil
+	}
+}
+
+// WithThrottleOnBlurOption see Application.SetThrottleOnBlur.
+func WithThrottleOnBlurOption(enabled bool) Option {
+	return func(a *Application) error {
+		a.throttleOnBlur = enabled
+		return nil
+	}
+}
+
+// WithFixedTimestepOption calls Layer.OnUpdate at a fixed rate of
+// updatesPerSecond, decoupled from the frame rate. Layer.OnRender
+// is still called once per frame.
+//
+// A frame can run zero or several updates, so one-shot input such
+// as window.IsKeyPressed is better handled in OnRender.
+func WithFixedTimestepOption(updatesPerSecond float64) Option {
+	return func(a *Application) error {
+		if updatesPerSecond <= 0 {
+			return fmt.Errorf("invalid fixed timestep rate: %f", updatesPerSecond)
+		}
+		a.fixedTimestep = 1.0 / updatesPerSecond
+		return nil
+	}
+}
@@ -0,0 +1,53 @@
+package renderer
+
+import (
+	"fmt"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// BlendMode is a preset of blending factors, see SetBlendMode.
+type BlendMode int
+
+// Blend modes
+const (
+	// BlendNone disables blending, sources overwrite destinations.
+	BlendNone BlendMode = iota
+	// BlendAlpha interpolates between destination and source with
+	// the source alpha, for colors that are not premultiplied.
+	BlendAlpha
+	// BlendPremultipliedAlpha expects source colors already multiplied
+	// by their alpha, which avoids dark fringes on filtered edges.
+	BlendPremultipliedAlpha
+	// BlendAdditive adds the source weighted by its alpha to the
+	// destination, brightening it, for particles, glows and lights.
+	BlendAdditive
+	// BlendMultiply multiplies the destination by the source color,
+	// darkening it, for shadows and tinting. Alpha is ignored.
+	BlendMultiply
+)
+
+// SetBlendMode enables blending with the factors of the preset,
+// always combined with gl.FUNC_ADD.
+func (r *Renderer) SetBlendMode(mode BlendMode) error {
+	var src, dst uint32
+	switch mode {
+	case BlendNone:
+		r.SetBlending(false)
+		return nil
+	case BlendAlpha:
+		src, dst = gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA
+	case BlendPremultipliedAlpha:
+		src, dst = gl.ONE, gl.ONE_MINUS_SRC_ALPHA
+	case BlendAdditive:
+		src, dst = gl.SRC_ALPHA, gl.ONE
+	case BlendMultiply:
+		src, dst = gl.DST_COLOR, gl.ZERO
+	default:
+		return fmt.Errorf("invalid blend mode: %d", mode)
+	}
+	gl.BlendEquation(gl.FUNC_ADD)
+	r.SetBlendFunc(src, dst)
+	r.SetBlending(true)
+	return nil
+}
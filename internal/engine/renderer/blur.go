@@ -0,0 +1,186 @@
+package renderer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/devodev/opengl-experiment/internal/opengl"
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+var (
+	defaultBlurRadius     = 4
+	defaultBlurIterations = 1
+	maxBlurRadius         = 32
+)
+
+// blurFragmentShader samples radius texels on each side along
+// direction, one texel in texture coordinates, weighted by weights.
+const blurFragmentShader = `
+#version 460 core
+layout (location = 0) out vec4 fragColor;
+
+in vec2 fragTexCoord;
+
+uniform sampler2D screenTexture;
+uniform vec2 direction;
+uniform int radius;
+uniform float weights[33];
+
+void main() {
+    vec4 color = texture(screenTexture, fragTexCoord) * weights[0];
+    for (int i = 1; i <= radius; i++) {
+        vec2 offset = direction * float(i);
+        color += texture(screenTexture, fragTexCoord + offset) * weights[i];
+        color += texture(screenTexture, fragTexCoord - offset) * weights[i];
+    }
+    fragColor = color;
+}
+    `
+
+// BlurPass applies a separable Gaussian blur, alternating horizontal
+// and vertical passes between two floating point framebuffers.
+type BlurPass struct {
+	framebuffers  [2]*opengl.Framebuffer
+	shaderProgram *opengl.ShaderProgram
+	radius        int
+	iterations    int
+	weights       []float32
+}
+
+// NewBlurPass creates a pass rendering into width by height textures.
+func NewBlurPass(width, height int) (*BlurPass, error) {
+	shaderProgram, err := NewPostProcessShaderProgram(blurFragmentShader + "\x00")
+	if err != nil {
+		return nil, err
+	}
+	b := &BlurPass{
+		shaderProgram: shaderProgram,
+		iterations:    defaultBlurIterations,
+	}
+	if err := b.Resize(width, height); err != nil {
+		shaderProgram.Delete()
+		return nil, err
+	}
+	if err := b.SetRadius(defaultBlurRadius); err != nil {
+		b.Delete()
+		return nil, err
+	}
+	return b, nil
+}
+
+// Resize recreates the framebuffers, usually
+// to match a new window framebuffer size.
+func (b *BlurPass) Resize(width, height int) error {
+	var framebuffers [2]*opengl.Framebuffer
+	for i := range framebuffers {
+		fbo, err := opengl.NewFramebufferWithOptions(width, height, opengl.FramebufferOptions{ColorCount: 1, ColorFormat: gl.RGBA16F})
+		if err != nil {
+			if i > 0 {
+				framebuffers[0].Delete()
+			}
+			return fmt.Errorf("error creating blur framebuffer: %s", err)
+		}
+		framebuffers[i] = fbo
+	}
+	b.deleteFramebuffers()
+	b.framebuffers = framebuffers
+	return nil
+}
+
+// SetRadius sets the number of texels sampled on each side
+// of a pixel, up to 32, the Gaussian sigma being half of it.
+func (b *BlurPass) SetRadius(radius int) error {
+	if radius < 1 || radius > maxBlurRadius {
+		return fmt.Errorf("invalid blur radius: %d != 1 <= x <= %d", radius, maxBlurRadius)
+	}
+	b.radius = radius
+	b.weights = gaussianWeights(radius)
+	return nil
+}
+
+// GetRadius .
+func (b *BlurPass) GetRadius() int {
+	return b.radius
+}
+
+// SetIterations sets how many times both passes are applied,
+// blurring more without increasing the radius.
+func (b *BlurPass) SetIterations(iterations int) error {
+	if iterations < 1 {
+		return fmt.Errorf("invalid blur iterations: %d", iterations)
+	}
+	b.iterations = iterations
+	return nil
+}
+
+// Apply blurs inputTexture and returns the ID of the blurred texture,
+// owned by the pass and overwritten by the next call. The framebuffer
+// and viewport bound before the call are restored.
+func (b *BlurPass) Apply(r *Renderer, inputTexture uint32) uint32 {
+	var previousFramebuffer int32
+	gl.GetIntegerv(gl.FRAMEBUFFER_BINDING, &previousFramebuffer)
+	var viewport [4]int32
+	gl.GetIntegerv(gl.VIEWPORT, &viewport[0])
+	defer func() {
+		gl.BindFramebuffer(gl.FRAMEBUFFER, uint32(previousFramebuffer))
+		gl.Viewport(viewport[0], viewport[1], viewport[2], viewport[3])
+	}()
+
+	width, height := b.framebuffers[0].GetSize()
+	b.shaderProgram.Bind()
+	b.shaderProgram.SetUniform1i("radius", int32(b.radius))
+	b.shaderProgram.SetUniform1fv("weights", int32(len(b.weights)), &b.weights[0])
+
+	texture := inputTexture
+	for i := 0; i < b.iterations; i++ {
+		for pass, fbo := range b.framebuffers {
+			fbo.Bind()
+			b.shaderProgram.Bind()
+			if pass == 0 {
+				b.shaderProgram.SetUniform2f("direction", 1/float32(width), 0)
+			} else {
+				b.shaderProgram.SetUniform2f("direction", 0, 1/float32(height))
+			}
+			r.DrawPostProcess(b.shaderProgram, texture)
+			texture = fbo.GetColorTexture()
+		}
+	}
+	return texture
+}
+
+// Delete .
+func (b *BlurPass) Delete() {
+	b.deleteFramebuffers()
+	b.shaderProgram.Delete()
+}
+
+func (b *BlurPass) deleteFramebuffers() {
+	for i, fbo := range b.framebuffers {
+		if fbo != nil {
+			fbo.Delete()
+			b.framebuffers[i] = nil
+		}
+	}
+}
+
+// gaussianWeights returns the radius+1 weights of one side of a
+// normalized Gaussian kernel, the first being the center.
+func gaussianWeights(radius int) []float32 {
+	sigma := math.Max(float64(radius)/2, 1)
+	weights := make([]float32, radius+1)
+	var sum float64
+	for i := range weights {
+		w := math.Exp(-float64(i*i) / (2 * sigma * sigma))
+		weights[i] = float32(w)
+		if i == 0 {
+			sum += w
+		} else {
+			sum += 2 * w
+		}
+	}
+	for i := range weights {
+		weights[i] = float32(float64(weights[i]) / sum)
+	}
+	return weights
+}
@@ -0,0 +1,191 @@
+package renderer
+
+import (
+	"math"
+
+	"github.com/devodev/opengl-experiment/internal/opengl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// AABB is an axis-aligned bounding box.
+type AABB struct {
+	Min mgl32.Vec3
+	Max mgl32.Vec3
+}
+
+// Center .
+func (b AABB) Center() mgl32.Vec3 {
+	return b.Min.Add(b.Max).Mul(0.5)
+}
+
+// Radius returns the radius of the sphere centered
+// on the box and going through its corners.
+func (b AABB) Radius() float32 {
+	return b.Max.Sub(b.Min).Len() / 2
+}
+
+// Transform returns the box bounding this one once transformed
+// by m, larger than the transformed box when rotated.
+func (b AABB) Transform(m mgl32.Mat4) AABB {
+	result := emptyAABB()
+	for i := 0; i < 8; i++ {
+		corner := b.Min
+		if i&1 != 0 {
+			corner[0] = b.Max[0]
+		}
+		if i&2 != 0 {
+			corner[1] = b.Max[1]
+		}
+		if i&4 != 0 {
+			corner[2] = b.Max[2]
+		}
+		result.extend(mgl32.TransformCoordinate(corner, m))
+	}
+	return result
+}
+
+// Intersects reports whether the box is at least partially inside
+// the frustum. Boxes near the corners of the frustum may be reported
+// as intersecting while being outside, which is safe for culling.
+func (b AABB) Intersects(frustum *Frustum) bool {
+	for _, plane := range frustum.planes {
+		// the corner furthest along the plane normal
+		corner := b.Min
+		for axis := 0; axis < 3; axis++ {
+			if plane[axis] >= 0 {
+				corner[axis] = b.Max[axis]
+			}
+		}
+		if plane.Vec3().Dot(corner)+plane[3] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// RayAABBIntersect reports whether the ray hits the box, along with
+// the distance from origin to the hit point along direction, in
+// units of its length. A ray starting inside the box hits it at 0.
+func RayAABBIntersect(origin, direction mgl32.Vec3, min, max mgl32.Vec3) (bool, float32) {
+	// slab method, intersecting the ray with the
+	// pairs of planes bounding each axis
+	tNear := float32(math.Inf(-1))
+	tFar := float32(math.Inf(1))
+	for axis := 0; axis < 3; axis++ {
+		if direction[axis] == 0 {
+			// parallel to the slab, it must start between its planes
+			if origin[axis] < min[axis] || origin[axis] > max[axis] {
+				return false, 0
+			}
+			continue
+		}
+		t1 := (min[axis] - origin[axis]) / direction[axis]
+		t2 := (max[axis] - origin[axis]) / direction[axis]
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		if t1 > tNear {
+			tNear = t1
+		}
+		if t2 < tFar {
+			tFar = t2
+		}
+		if tNear > tFar || tFar < 0 {
+			return false, 0
+		}
+	}
+	if tNear < 0 {
+		return true, 0
+	}
+	return true, tNear
+}
+
+// emptyAABB is inverted so that extending it
+// with a point results in a box holding only the point.
+func emptyAABB() AABB {
+	inf := float32(math.Inf(1))
+	return AABB{
+		Min: mgl32.Vec3{inf, inf, inf},
+		Max: mgl32.Vec3{-inf, -inf, -inf},
+	}
+}
+
+func (b *AABB) extend(point mgl32.Vec3) {
+	for axis := 0; axis < 3; axis++ {
+		if point[axis] < b.Min[axis] {
+			b.Min[axis] = point[axis]
+		}
+		if point[axis] > b.Max[axis] {
+			b.Max[axis] = point[axis]
+		}
+	}
+}
+
+// Frustum is the volume visible through a camera, bounded
+// by six planes whose normals point inside.
+type Frustum struct {
+	// left, right, bottom, top, near and far planes as
+	// (normal, distance) with normalized normals
+	planes [6]mgl32.Vec4
+}
+
+// NewFrustum extracts the planes of the frustum from a
+// view-projection matrix, ex: CameraController.GetViewProjectionMatrix.
+// Planes are in world space, or in the space of the
+// model when a model matrix is included.
+func NewFrustum(viewProjection mgl32.Mat4) *Frustum {
+	row0 := viewProjection.Row(0)
+	row1 := viewProjection.Row(1)
+	row2 := viewProjection.Row(2)
+	row3 := viewProjection.Row(3)
+	f := &Frustum{
+		planes: [6]mgl32.Vec4{
+			row3.Add(row0),
+			row3.Sub(row0),
+			row3.Add(row1),
+			row3.Sub(row1),
+			row3.Add(row2),
+			row3.Sub(row2),
+		},
+	}
+	for i, plane := range f.planes {
+		if length := plane.Vec3().Len(); length > 0 {
+			f.planes[i] = plane.Mul(1 / length)
+		}
+	}
+	return f
+}
+
+// ContainsPoint .
+func (f *Frustum) ContainsPoint(point mgl32.Vec3) bool {
+	for _, plane := range f.planes {
+		if plane.Vec3().Dot(point)+plane[3] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// computeAABB bounds the positions of the vertices, read from the
+// first attribute of the layout which must hold 2 or 3 floats.
+// It returns nil when the positions cannot be read.
+func computeAABB(vertices []float32, layout *opengl.VBOLayout) *AABB {
+	elements := layout.GetElements()
+	if len(elements) == 0 || len(vertices) == 0 {
+		return nil
+	}
+	position := elements[0]
+	if position.DataType != opengl.GLDataTypeFloat || position.Count < 2 || position.Count > 3 {
+		return nil
+	}
+	floatsPerVertex := int(layout.GetStride()) / 4
+	offset := position.GetOffset() / 4
+
+	bounds := emptyAABB()
+	for i := offset; i+int(position.Count) <= len(vertices); i += floatsPerVertex {
+		var point mgl32.Vec3
+		copy(point[:], vertices[i:i+int(position.Count)])
+		bounds.extend(point)
+	}
+	return &bounds
+}
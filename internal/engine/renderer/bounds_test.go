@@ -0,0 +1,55 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// newTestFrustum looks down -Z from the origin with a 90 degrees
+// field of view, between the near plane at 1 and the far plane at 100.
+func newTestFrustum() *Frustum {
+	projection := mgl32.Perspective(mgl32.DegToRad(90), 1, 1, 100)
+	view := mgl32.LookAtV(mgl32.Vec3{0, 0, 0}, mgl32.Vec3{0, 0, -1}, mgl32.Vec3{0, 1, 0})
+	return NewFrustum(projection.Mul4(view))
+}
+
+func TestAABBIntersects(t *testing.T) {
+	cases := []struct {
+		name string
+		box  AABB
+		want bool
+	}{
+		{"inside", AABB{Min: mgl32.Vec3{-1, -1, -11}, Max: mgl32.Vec3{1, 1, -9}}, true},
+		{"behind", AABB{Min: mgl32.Vec3{-1, -1, 9}, Max: mgl32.Vec3{1, 1, 11}}, false},
+		{"left", AABB{Min: mgl32.Vec3{-30, -1, -11}, Max: mgl32.Vec3{-20, 1, -9}}, false},
+		{"beyond far plane", AABB{Min: mgl32.Vec3{-1, -1, -200}, Max: mgl32.Vec3{1, 1, -150}}, false},
+		{"straddling the left plane", AABB{Min: mgl32.Vec3{-15, -1, -11}, Max: mgl32.Vec3{-5, 1, -9}}, true},
+		{"straddling the near plane", AABB{Min: mgl32.Vec3{-1, -1, -2}, Max: mgl32.Vec3{1, 1, 2}}, true},
+		{"enclosing the frustum", AABB{Min: mgl32.Vec3{-500, -500, -500}, Max: mgl32.Vec3{500, 500, 500}}, true},
+	}
+	frustum := newTestFrustum()
+	for _, c := range cases {
+		if got := c.box.Intersects(frustum); got != c.want {
+			t.Errorf("%s: Intersects() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestFrustumContainsPoint(t *testing.T) {
+	cases := []struct {
+		name  string
+		point mgl32.Vec3
+		want  bool
+	}{
+		{"center", mgl32.Vec3{0, 0, -10}, true},
+		{"before near plane", mgl32.Vec3{0, 0, -0.5}, false},
+		{"outside the field of view", mgl32.Vec3{0, 20, -10}, false},
+	}
+	frustum := newTestFrustum()
+	for _, c := range cases {
+		if got := frustum.ContainsPoint(c.point); got != c.want {
+			t.Errorf("%s: ContainsPoint() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
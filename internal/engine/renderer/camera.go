@@ -1,12 +1,20 @@
 package renderer
 
 import (
+	"fmt"
+	"math"
+
 	"github.com/go-gl/mathgl/mgl32"
 )
 
 var (
 	defaultCameraPerspectiveFov = mgl32.DegToRad(45.0)
+	minCameraPerspectiveFov     = mgl32.DegToRad(1.0)
+	maxCameraPerspectiveFov     = mgl32.DegToRad(45.0)
 	defaultCameraZoomLevel      = float32(1.0)
+	minCameraZoomLevel          = float32(0.25)
+	maxCameraZoomLevel          = float32(10.0)
+	cameraZoomSpeed             = float32(0.1)
 	defaultCameraNear           = float32(0.1)
 	defaultCameraFar            = float32(10.0)
 )
@@ -14,8 +22,39 @@ var (
 // Camera .
 type Camera interface {
 	Resize(int, int)
+	// Zoom zooms in for positive offsets, out otherwise
+	Zoom(float32)
 	GetProjectionMatrix() mgl32.Mat4
 	GetViewPortDimensions() (int, int)
+	// GetClipPlanes returns the near and far plane distances
+	GetClipPlanes() (float32, float32)
+	// SetClipPlanes returns an error when far is not beyond near
+	SetClipPlanes(near, far float32) error
+}
+
+// sphereFitter is implemented by cameras able to fit a sphere in
+// view, returning the distance it must be looked at from.
+type sphereFitter interface {
+	fitSphere(radius float32) float32
+}
+
+// clipPlanesAround returns clip planes keeping a sphere of radius
+// looked at from distance well within the depth range.
+func clipPlanesAround(distance, radius float32) (float32, float32) {
+	near := distance - 2*radius
+	if minNear := distance * 0.01; near < minNear {
+		near = minNear
+	}
+	return near, distance + 2*radius
+}
+
+// validatePerspectiveClipPlanes also rejects a near plane at or
+// behind the eye, which a perspective projection divides by.
+func validatePerspectiveClipPlanes(near, far float32) error {
+	if near <= 0 || far <= near {
+		return fmt.Errorf("invalid clip planes: near %f, far %f", near, far)
+	}
+	return nil
 }
 
 // CameraPerspective .
@@ -24,8 +63,12 @@ type CameraPerspective struct {
 	height      int
 	aspectRatio float32
 	fov         float32
-	near        float32
-	far         float32
+	// zooming is clamped to the range, which
+	// SetPerspective extends to its field of view
+	minFov float32
+	maxFov float32
+	near   float32
+	far    float32
 
 	projectionMatrix mgl32.Mat4
 }
@@ -33,22 +76,37 @@ type CameraPerspective struct {
 // NewCameraPerspective .
 func NewCameraPerspective(width, height int) *CameraPerspective {
 	camera := &CameraPerspective{
-		fov:  defaultCameraPerspectiveFov,
-		near: defaultCameraNear,
-		far:  defaultCameraFar,
+		aspectRatio: 1,
+		fov:         defaultCameraPerspectiveFov,
+		minFov:      minCameraPerspectiveFov,
+		maxFov:      maxCameraPerspectiveFov,
+		near:        defaultCameraNear,
+		far:         defaultCameraFar,
 	}
+	camera.recalculateProjectionMatrix()
 	camera.Resize(width, height)
 	return camera
 }
 
-// Resize .
+// Resize recomputes the aspect ratio when the dimensions change.
+// Empty dimensions, ex: from a minimized window, are ignored.
 func (c *CameraPerspective) Resize(width, height int) {
+	if width <= 0 || height <= 0 || (width == c.width && height == c.height) {
+		return
+	}
 	c.width = width
 	c.height = height
 	c.aspectRatio = float32(c.width) / float32(c.height)
 	c.recalculateProjectionMatrix()
 }
 
+// Zoom narrows the field of view, clamped between 1 and 45 degrees
+// or the field of view given to SetPerspective when outside.
+func (c *CameraPerspective) Zoom(offset float32) {
+	c.fov = mgl32.Clamp(c.fov-mgl32.DegToRad(offset), c.minFov, c.maxFov)
+	c.recalculateProjectionMatrix()
+}
+
 // GetProjectionMatrix .
 func (c *CameraPerspective) GetProjectionMatrix() mgl32.Mat4 {
 	return c.projectionMatrix
@@ -59,6 +117,65 @@ func (c *CameraPerspective) GetViewPortDimensions() (int, int) {
 	return c.width, c.height
 }
 
+// GetClipPlanes .
+func (c *CameraPerspective) GetClipPlanes() (float32, float32) {
+	return c.near, c.far
+}
+
+// SetClipPlanes .
+func (c *CameraPerspective) SetClipPlanes(near, far float32) error {
+	if err := validatePerspectiveClipPlanes(near, far); err != nil {
+		return err
+	}
+	c.near = near
+	c.far = far
+	c.recalculateProjectionMatrix()
+	return nil
+}
+
+// SetPerspective sets all the projection parameters at once, fovDeg
+// being the vertical field of view in degrees. The aspect ratio is
+// kept until the camera is resized to different dimensions. The
+// zoom range is extended to include the field of view, so that
+// zooming does not jump back within 1 and 45 degrees.
+func (c *CameraPerspective) SetPerspective(fovDeg, aspect, near, far float32) error {
+	if fovDeg <= 0 || fovDeg >= 180 {
+		return fmt.Errorf("invalid field of view: %f != 0 < x < 180", fovDeg)
+	}
+	if aspect <= 0 {
+		return fmt.Errorf("invalid aspect ratio: %f", aspect)
+	}
+	if err := validatePerspectiveClipPlanes(near, far); err != nil {
+		return err
+	}
+	c.fov = mgl32.DegToRad(fovDeg)
+	c.minFov = mgl32.Clamp(c.fov, 0, minCameraPerspectiveFov)
+	c.maxFov = mgl32.Clamp(c.fov, maxCameraPerspectiveFov, math.Pi)
+	c.aspectRatio = aspect
+	c.near = near
+	c.far = far
+	c.recalculateProjectionMatrix()
+	return nil
+}
+
+// GetFov returns the vertical field of view in radians.
+func (c *CameraPerspective) GetFov() float32 {
+	return c.fov
+}
+
+// fitSphere uses the narrowest of the vertical and horizontal
+// fields of view, so that the sphere fits both ways.
+func (c *CameraPerspective) fitSphere(radius float32) float32 {
+	fov := c.fov
+	if c.aspectRatio < 1 {
+		fov = 2 * float32(math.Atan(math.Tan(float64(c.fov/2))*float64(c.aspectRatio)))
+	}
+	distance := radius / sin(fov/2)
+	// always valid, the distance is positive
+	_ = c.SetClipPlanes(clipPlanesAround(distance, radius))
+	return distance
+}
+
 func (c *CameraPerspective) recalculateProjectionMatrix() {
 	c.projectionMatrix = mgl32.Perspective(c.fov, c.aspectRatio, c.near, c.far)
 }
@@ -78,22 +195,34 @@ type CameraOrthographic struct {
 // NewCameraOrthographic .
 func NewCameraOrthographic(width, height int) *CameraOrthographic {
 	camera := &CameraOrthographic{
-		zoomLevel: defaultCameraZoomLevel,
-		near:      defaultCameraNear,
-		far:       defaultCameraFar,
+		aspectRatio: 1,
+		zoomLevel:   defaultCameraZoomLevel,
+		near:        defaultCameraNear,
+		far:         defaultCameraFar,
 	}
+	camera.recalculateProjectionMatrix()
 	camera.Resize(width, height)
 	return camera
 }
 
-// Resize .
+// Resize recomputes the aspect ratio when the dimensions change.
+// Empty dimensions, ex: from a minimized window, are ignored.
 func (c *CameraOrthographic) Resize(width, height int) {
+	if width <= 0 || height <= 0 || (width == c.width && height == c.height) {
+		return
+	}
 	c.width = width
 	c.height = height
 	c.aspectRatio = float32(c.width) / float32(c.height)
 	c.recalculateProjectionMatrix()
 }
 
+// Zoom .
+func (c *CameraOrthographic) Zoom(offset float32) {
+	c.zoomLevel = mgl32.Clamp(c.zoomLevel-offset*cameraZoomSpeed*c.zoomLevel, minCameraZoomLevel, maxCameraZoomLevel)
+	c.recalculateProjectionMatrix()
+}
+
 // GetProjectionMatrix .
 func (c *CameraOrthographic) GetProjectionMatrix() mgl32.Mat4 {
 	return c.projectionMatrix
@@ -104,6 +233,126 @@ func (c *CameraOrthographic) GetViewPortDimensions() (int, int) {
 	return c.width, c.height
 }
 
+// GetClipPlanes .
+func (c *CameraOrthographic) GetClipPlanes() (float32, float32) {
+	return c.near, c.far
+}
+
+// SetClipPlanes accepts a near plane behind the eye,
+// which an orthographic projection allows.
+func (c *CameraOrthographic) SetClipPlanes(near, far float32) error {
+	if far <= near {
+		return fmt.Errorf("invalid clip planes: near %f, far %f", near, far)
+	}
+	c.near = near
+	c.far = far
+	c.recalculateProjectionMatrix()
+	return nil
+}
+
+// fitSphere sets the zoom level so that the sphere fits both ways,
+// the distance only matters for the clip planes. The zoom level
+// is not clamped, zooming afterwards brings it back in range.
+func (c *CameraOrthographic) fitSphere(radius float32) float32 {
+	c.zoomLevel = radius
+	if c.aspectRatio < 1 {
+		c.zoomLevel = radius / c.aspectRatio
+	}
+	distance := 2 * radius
+	_ = c.SetClipPlanes(clipPlanesAround(distance, radius))
+	return distance
+}
+
 func (c *CameraOrthographic) recalculateProjectionMatrix() {
 	c.projectionMatrix = mgl32.Ortho((-c.aspectRatio)*c.zoomLevel, c.aspectRatio*c.zoomLevel, -c.zoomLevel, c.zoomLevel, c.near, c.far)
 }
+
+// ProjectionMode selects the projection used by CameraSwitchable.
+type ProjectionMode int
+
+// Projection modes
+const (
+	ProjectionPerspective ProjectionMode = iota
+	ProjectionOrthographic
+)
+
+// CameraSwitchable holds both a perspective and an orthographic
+// camera and forwards to the one selected by its projection mode.
+// Both are resized so that the aspect ratio is up to date when
+// switching modes, zooming only affects the active one.
+type CameraSwitchable struct {
+	mode         ProjectionMode
+	perspective  *CameraPerspective
+	orthographic *CameraOrthographic
+}
+
+// NewCameraSwitchable .
+func NewCameraSwitchable(width, height int, mode ProjectionMode) *CameraSwitchable {
+	return &CameraSwitchable{
+		mode:         mode,
+		perspective:  NewCameraPerspective(width, height),
+		orthographic: NewCameraOrthographic(width, height),
+	}
+}
+
+// SetProjectionMode .
+func (c *CameraSwitchable) SetProjectionMode(mode ProjectionMode) {
+	c.mode = mode
+}
+
+// GetProjectionMode .
+func (c *CameraSwitchable) GetProjectionMode() ProjectionMode {
+	return c.mode
+}
+
+// Resize .
+func (c *CameraSwitchable) Resize(width, height int) {
+	c.perspective.Resize(width, height)
+	c.orthographic.Resize(width, height)
+}
+
+// Zoom .
+func (c *CameraSwitchable) Zoom(offset float32) {
+	c.active().Zoom(offset)
+}
+
+// GetProjectionMatrix .
+func (c *CameraSwitchable) GetProjectionMatrix() mgl32.Mat4 {
+	return c.active().GetProjectionMatrix()
+}
+
+// GetViewPortDimensions .
+func (c *CameraSwitchable) GetViewPortDimensions() (int, int) {
+	return c.active().GetViewPortDimensions()
+}
+
+// GetClipPlanes .
+func (c *CameraSwitchable) GetClipPlanes() (float32, float32) {
+	return c.active().GetClipPlanes()
+}
+
+// SetClipPlanes sets the clip planes of both cameras, or
+// neither when they are invalid for the perspective one.
+func (c *CameraSwitchable) SetClipPlanes(near, far float32) error {
+	if err := c.perspective.SetClipPlanes(near, far); err != nil {
+		return err
+	}
+	return c.orthographic.SetClipPlanes(near, far)
+}
+
+// fitSphere fits both cameras so that switching modes keeps the
+// sphere in view. They are looked through from the same position,
+// at the distance needed by the perspective camera.
+func (c *CameraSwitchable) fitSphere(radius float32) float32 {
+	distance := c.perspective.fitSphere(radius)
+	c.orthographic.fitSphere(radius)
+	_ = c.orthographic.SetClipPlanes(clipPlanesAround(distance, radius))
+	return distance
+}
+
+func (c *CameraSwitchable) active() Camera {
+	if c.mode == ProjectionOrthographic {
+		return c.orthographic
+	}
+	return c.perspective
+}
@@ -15,6 +15,16 @@ var (
 	defaultControllerRotationSensitivity = float32(2)
 	defaultControllerYaw                 = float32(-90.0)
 	defaultControllerPitch               = float32(0.0)
+
+	// degrees per second at full stick deflection
+	defaultControllerGamepadLookSpeed = float32(90.0)
+
+	// past 90 degrees the view flips upside down
+	controllerMaxPitch = float32(89.0)
+
+	// FrameBounds looks at the front of the box, slightly from above
+	frameBoundsYaw   = float32(-90.0)
+	frameBoundsPitch = float32(-25.0)
 )
 
 func sin(v float32) float32 {
@@ -37,6 +47,7 @@ type CameraController struct {
 	mousePosX             float64
 	mousePosY             float64
 	mouseButton1IsPressed bool
+	mouseLook             bool
 
 	viewMatrix mgl32.Mat4
 
@@ -46,7 +57,7 @@ type CameraController struct {
 // NewCameraController .
 func NewCameraController(camera Camera) *CameraController {
 	width, height := camera.GetViewPortDimensions()
-	return &CameraController{
+	controller := &CameraController{
 		pos:                   defaultControllerPos,
 		target:                defaultControllerTarget,
 		up:                    defaultControllerUp,
@@ -59,10 +70,15 @@ func NewCameraController(camera Camera) *CameraController {
 		mouseButton1IsPressed: false,
 		camera:                camera,
 	}
+	controller.recalculateViewMatrix()
+	return controller
 }
 
 // OnUpdate .
 func (c *CameraController) OnUpdate(w *window.Window, deltaTime float64) {
+	// follow the framebuffer size even when unfocused,
+	// the window can be resized from the background
+	c.camera.Resize(w.GetFramebufferSize())
 	if !w.IsFocused() {
 		return
 	}
@@ -70,38 +86,64 @@ func (c *CameraController) OnUpdate(w *window.Window, deltaTime float64) {
 	// speed
 	speed := c.baseSpeed * float32(deltaTime)
 	// position
-	if w.IsKeyPressed(window.KeyW) {
+	if w.IsActionActive(window.ActionForward) {
 		c.moveForward(speed)
 	}
-	if w.IsKeyPressed(window.KeyS) {
+	if w.IsActionActive(window.ActionBackward) {
 		c.moveBackward(speed)
 	}
-	if w.IsKeyPressed(window.KeyA) {
+	if w.IsActionActive(window.ActionLeft) {
 		c.moveLeft(speed)
 	}
-	if w.IsKeyPressed(window.KeyD) {
+	if w.IsActionActive(window.ActionRight) {
 		c.moveRight(speed)
 	}
-	// rotation
+	// gamepad
+	if gamepad := w.GetGamepad(); gamepad != nil {
+		c.moveForward(-gamepad.GetAxis(window.GamepadAxisLeftY) * speed)
+		c.moveRight(gamepad.GetAxis(window.GamepadAxisLeftX) * speed)
+
+		lookSpeed := defaultControllerGamepadLookSpeed * float32(deltaTime)
+		c.yaw += gamepad.GetAxis(window.GamepadAxisRightX) * lookSpeed
+		c.pitch -= gamepad.GetAxis(window.GamepadAxisRightY) * lookSpeed
+		c.pitch = mgl32.Clamp(c.pitch, -controllerMaxPitch, controllerMaxPitch)
+		c.recalculateTarget()
+	}
+	// rotation, following the mouse while the cursor is disabled,
+	// otherwise while dragging inside the window
 	windowWidth, windowHeight := w.GetSize()
 	cursorX, cursorY := w.GetCursorPos()
-	if cursorX >= 0 &&
-		cursorY >= 0 &&
-		cursorX <= float64(windowWidth) &&
-		cursorY <= float64(windowHeight) {
-		if w.IsMouseButtonPressed(window.MouseButton1) {
-			if !c.mouseButton1IsPressed {
-				c.mouseButton1IsPressed = true
-				c.mousePosX = cursorX
-				c.mousePosY = cursorY
+	if w.GetCursorMode() == window.CursorDisabled {
+		if !c.mouseLook {
+			c.mouseLook = true
+			c.mousePosX = cursorX
+			c.mousePosY = cursorY
+		}
+		c.rotate(speed, cursorX, cursorY)
+	} else {
+		c.mouseLook = false
+		if cursorX >= 0 &&
+			cursorY >= 0 &&
+			cursorX <= float64(windowWidth) &&
+			cursorY <= float64(windowHeight) {
+			if w.IsActionActive(window.ActionRotate) {
+				if !c.mouseButton1IsPressed {
+					c.mouseButton1IsPressed = true
+					c.mousePosX = cursorX
+					c.mousePosY = cursorY
+				}
+				c.rotate(speed, cursorX, cursorY)
+			} else {
+				c.mouseButton1IsPressed = false
 			}
-			c.rotate(speed, cursorX, cursorY)
-		} else {
-			c.mouseButton1IsPressed = false
 		}
 	}
 
-	c.camera.Resize(windowWidth, windowHeight)
+	// zoom
+	if _, scrollY := w.GetScrollOffset(); scrollY != 0 {
+		c.camera.Zoom(float32(scrollY))
+	}
+
 	c.recalculateViewMatrix()
 }
 
@@ -110,6 +152,94 @@ func (c *CameraController) GetViewProjectionMatrix() mgl32.Mat4 {
 	return c.camera.GetProjectionMatrix().Mul4(c.viewMatrix)
 }
 
+// GetViewMatrix .
+func (c *CameraController) GetViewMatrix() mgl32.Mat4 {
+	return c.viewMatrix
+}
+
+// GetProjectionMatrix .
+func (c *CameraController) GetProjectionMatrix() mgl32.Mat4 {
+	return c.camera.GetProjectionMatrix()
+}
+
+// GetCamera .
+func (c *CameraController) GetCamera() Camera {
+	return c.camera
+}
+
+// GetPosition .
+func (c *CameraController) GetPosition() mgl32.Vec3 {
+	return c.pos
+}
+
+// SetPosition .
+func (c *CameraController) SetPosition(pos mgl32.Vec3) {
+	c.pos = pos
+	c.recalculateViewMatrix()
+}
+
+// GetFront returns the direction the camera is looking at.
+func (c *CameraController) GetFront() mgl32.Vec3 {
+	return c.target.Normalize()
+}
+
+// SetSpeed sets the movement speed in units per second.
+func (c *CameraController) SetSpeed(speed float32) {
+	c.baseSpeed = speed
+}
+
+// SetRotationSensitivity .
+func (c *CameraController) SetRotationSensitivity(sensitivity float32) {
+	c.rotationSensitivity = sensitivity
+}
+
+// GetUp .
+func (c *CameraController) GetUp() mgl32.Vec3 {
+	return c.up
+}
+
+// ScreenPointToRay returns the world space ray going through a point
+// of the window, ex: the cursor position, in pixels from its top left
+// corner. The ray starts on the near plane, its direction is normalized.
+// A window without area, ex: minimized, yields the camera forward ray.
+func (c *CameraController) ScreenPointToRay(x, y float64, windowWidth, windowHeight int) (mgl32.Vec3, mgl32.Vec3) {
+	if windowWidth <= 0 || windowHeight <= 0 {
+		return c.pos, c.GetFront()
+	}
+	ndcX := float32(2*x/float64(windowWidth) - 1)
+	ndcY := float32(1 - 2*y/float64(windowHeight))
+	inverse := c.GetViewProjectionMatrix().Inv()
+	near := mgl32.TransformCoordinate(mgl32.Vec3{ndcX, ndcY, -1}, inverse)
+	far := mgl32.TransformCoordinate(mgl32.Vec3{ndcX, ndcY, 1}, inverse)
+	return near, far.Sub(near).Normalize()
+}
+
+// FrameBounds moves the camera to look at the center of the box from
+// the front and slightly above, far enough for the whole box to be in
+// view. The clip planes of the camera are set around the box.
+func (c *CameraController) FrameBounds(min, max mgl32.Vec3) {
+	bounds := AABB{Min: min, Max: max}
+	center := bounds.Center()
+	radius := bounds.Radius()
+	if radius <= 0 {
+		// a point, or an empty mesh
+		radius = 1
+	}
+	distance := 2 * radius
+	if fitter, ok := c.camera.(sphereFitter); ok {
+		distance = fitter.fitSphere(radius)
+	} else {
+		// always valid, the radius is positive
+		_ = c.camera.SetClipPlanes(clipPlanesAround(distance, radius))
+	}
+
+	c.yaw = frameBoundsYaw
+	c.pitch = frameBoundsPitch
+	c.recalculateTarget()
+	c.pos = center.Sub(c.target.Mul(distance))
+	c.recalculateViewMatrix()
+}
+
 func (c *CameraController) rotate(speed float32, posX, posY float64) {
 	xOffset := posX - c.mousePosX
 	yOffset := c.mousePosY - posY
@@ -118,6 +248,7 @@ func (c *CameraController) rotate(speed float32, posX, posY float64) {
 
 	c.yaw -= float32(xOffset) * c.rotationSensitivity * speed
 	c.pitch -= float32(yOffset) * c.rotationSensitivity * speed
+	c.pitch = mgl32.Clamp(c.pitch, -controllerMaxPitch, controllerMaxPitch)
 
 	c.recalculateTarget()
 }
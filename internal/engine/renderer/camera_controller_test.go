@@ -0,0 +1,54 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestScreenPointToRay(t *testing.T) {
+	controller := NewCameraController(NewCameraPerspective(800, 600))
+	controller.SetPosition(mgl32.Vec3{1, 2, 3})
+
+	cases := []struct {
+		name          string
+		width, height int
+	}{
+		{"screen center", 800, 600},
+		{"zero sized window", 0, 0},
+	}
+	for _, c := range cases {
+		origin, direction := controller.ScreenPointToRay(float64(c.width)/2, float64(c.height)/2, c.width, c.height)
+		if direction.Sub(controller.GetFront()).Len() > 1e-4 {
+			t.Errorf("%s: direction = %v, want the camera front %v", c.name, direction, controller.GetFront())
+		}
+		// the origin lies on the forward ray, at the near plane at most
+		toOrigin := origin.Sub(controller.GetPosition())
+		if toOrigin.Cross(controller.GetFront()).Len() > 1e-4 {
+			t.Errorf("%s: origin %v is not on the forward ray", c.name, origin)
+		}
+	}
+}
+
+func TestRayAABBIntersect(t *testing.T) {
+	min, max := mgl32.Vec3{-1, -1, -1}, mgl32.Vec3{1, 1, 1}
+	cases := []struct {
+		name         string
+		origin       mgl32.Vec3
+		direction    mgl32.Vec3
+		wantHit      bool
+		wantDistance float32
+	}{
+		{"hit", mgl32.Vec3{0, 0, 5}, mgl32.Vec3{0, 0, -1}, true, 4},
+		{"hit at an angle", mgl32.Vec3{-5, 0, 0}, mgl32.Vec3{1, 0, 0}, true, 4},
+		{"miss", mgl32.Vec3{0, 5, 5}, mgl32.Vec3{0, 0, -1}, false, 0},
+		{"pointing away", mgl32.Vec3{0, 0, 5}, mgl32.Vec3{0, 0, 1}, false, 0},
+		{"inside the box", mgl32.Vec3{0, 0, 0}, mgl32.Vec3{0, 1, 0}, true, 0},
+	}
+	for _, c := range cases {
+		hit, distance := RayAABBIntersect(c.origin, c.direction, min, max)
+		if hit != c.wantHit || mgl32.Abs(distance-c.wantDistance) > 1e-5 {
+			t.Errorf("%s: RayAABBIntersect() = (%v, %f), want (%v, %f)", c.name, hit, distance, c.wantHit, c.wantDistance)
+		}
+	}
+}
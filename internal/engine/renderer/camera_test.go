@@ -0,0 +1,61 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestCameraPerspectiveSetClipPlanes(t *testing.T) {
+	cases := []struct {
+		name    string
+		near    float32
+		far     float32
+		wantErr bool
+	}{
+		{"valid", 0.1, 100, false},
+		{"near at the eye", 0, 100, true},
+		{"near behind the eye", -1, 100, true},
+		{"far before near", 10, 1, true},
+		{"far at near", 1, 1, true},
+	}
+	for _, c := range cases {
+		camera := NewCameraPerspective(800, 600)
+		wantNear, wantFar := camera.GetClipPlanes()
+		if !c.wantErr {
+			wantNear, wantFar = c.near, c.far
+		}
+		err := camera.SetClipPlanes(c.near, c.far)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%s: SetClipPlanes(%v, %v) error = %v, want error %v", c.name, c.near, c.far, err, c.wantErr)
+		}
+		if near, far := camera.GetClipPlanes(); near != wantNear || far != wantFar {
+			t.Errorf("%s: GetClipPlanes() = %v, %v, want %v, %v", c.name, near, far, wantNear, wantFar)
+		}
+	}
+}
+
+func TestCameraPerspectiveZoomAfterSetPerspective(t *testing.T) {
+	cases := []struct {
+		name    string
+		fovDeg  float32
+		offset  float32
+		wantDeg float32
+	}{
+		{"default range", 30, -1, 31},
+		{"wide field of view zooms in", 90, 1, 89},
+		{"wide field of view zooms out no further", 90, -1, 90},
+		{"narrow field of view zooms out", 0.5, -1, 1.5},
+		{"narrow field of view zooms in no further", 0.5, 1, 0.5},
+	}
+	for _, c := range cases {
+		camera := NewCameraPerspective(800, 600)
+		if err := camera.SetPerspective(c.fovDeg, 1, 0.1, 100); err != nil {
+			t.Fatalf("%s: SetPerspective() error = %v", c.name, err)
+		}
+		camera.Zoom(c.offset)
+		if got := mgl32.RadToDeg(camera.GetFov()); !mgl32.FloatEqualThreshold(got, c.wantDeg, 1e-4) {
+			t.Errorf("%s: fov after Zoom(%v) = %v, want %v", c.name, c.offset, got, c.wantDeg)
+		}
+	}
+}
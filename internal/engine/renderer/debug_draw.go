@@ -0,0 +1,217 @@
+package renderer
+
+import (
+	"math"
+
+	"github.com/devodev/opengl-experiment/internal/opengl"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+var (
+	defaultDebugDrawMaxLines = 4096
+	// segments per circle of Sphere
+	debugDrawSphereSegments = 32
+)
+
+// The color shader expects positions (vec3) at location 0
+// and colors (vec3) at location 1, transformed by vp (mat4).
+const (
+	colorVertexShader = `
+#version 460 core
+layout (location = 0) in vec3 position;
+layout (location = 1) in vec3 color;
+
+out vec3 fragColor;
+
+uniform mat4 vp;
+
+void main() {
+    fragColor = color;
+    gl_Position = vp * vec4(position, 1.0);
+}
+    `
+
+	colorFragmentShader = `
+#version 460 core
+layout (location = 0) out vec4 outColor;
+
+in vec3 fragColor;
+
+void main() {
+    outColor = vec4(fragColor, 1.0);
+}
+    `
+)
+
+// DebugDraw batches line segments in world space and draws
+// them all at once, to visualize normals, bounds and such.
+// Shapes are queued from anywhere during a frame and drawn
+// by a single Flush, which is up to the owner of the DebugDraw.
+// The renderer has its own, flushed every frame, see DrawTriangle.
+type DebugDraw struct {
+	mesh          *DynamicMesh
+	shaderProgram *opengl.ShaderProgram
+
+	vertices []float32
+}
+
+// NewDebugDraw .
+func NewDebugDraw() (*DebugDraw, error) {
+	layout := opengl.NewVBOLayout(
+		opengl.VBOLayoutElement{Count: 3, Normalized: false, DataType: opengl.GLDataTypeFloat},
+		opengl.VBOLayoutElement{Count: 3, Normalized: false, DataType: opengl.GLDataTypeFloat},
+	)
+	mesh, err := NewDynamicMeshArrays(layout, defaultDebugDrawMaxLines*2)
+	if err != nil {
+		return nil, err
+	}
+	if err := mesh.SetPrimitive(gl.LINES); err != nil {
+		mesh.Delete()
+		return nil, err
+	}
+	shaderProgram, err := opengl.NewShaderProgram(colorVertexShader+"\x00", colorFragmentShader+"\x00")
+	if err != nil {
+		mesh.Delete()
+		return nil, err
+	}
+	d := &DebugDraw{
+		mesh:          mesh,
+		shaderProgram: shaderProgram,
+	}
+	return d, nil
+}
+
+// Line queues a segment from a to b.
+func (d *DebugDraw) Line(a, b mgl32.Vec3, color mgl32.Vec3) {
+	d.vertices = append(d.vertices,
+		a[0], a[1], a[2], color[0], color[1], color[2],
+		b[0], b[1], b[2], color[0], color[1], color[2],
+	)
+}
+
+// Axes queues red, green and blue segments of the
+// given length along the X, Y and Z axes from origin.
+func (d *DebugDraw) Axes(origin mgl32.Vec3, length float32) {
+	d.Line(origin, origin.Add(mgl32.Vec3{length, 0, 0}), mgl32.Vec3{1, 0, 0})
+	d.Line(origin, origin.Add(mgl32.Vec3{0, length, 0}), mgl32.Vec3{0, 1, 0})
+	d.Line(origin, origin.Add(mgl32.Vec3{0, 0, length}), mgl32.Vec3{0, 0, 1})
+}
+
+// Triangle queues the outline of the triangle abc.
+func (d *DebugDraw) Triangle(a, b, c mgl32.Vec3, color mgl32.Vec3) {
+	d.Line(a, b, color)
+	d.Line(b, c, color)
+	d.Line(c, a, color)
+}
+
+// Box queues the 12 edges of the axis-aligned box from min to max.
+func (d *DebugDraw) Box(min, max mgl32.Vec3, color mgl32.Vec3) {
+	corner := func(i int) mgl32.Vec3 {
+		c := min
+		if i&1 != 0 {
+			c[0] = max[0]
+		}
+		if i&2 != 0 {
+			c[1] = max[1]
+		}
+		if i&4 != 0 {
+			c[2] = max[2]
+		}
+		return c
+	}
+	for i := 0; i < 8; i++ {
+		// connect each corner to the corners differing by one axis,
+		// once per edge
+		for bit := 1; bit < 8; bit <<= 1 {
+			if i&bit == 0 {
+				d.Line(corner(i), corner(i|bit), color)
+			}
+		}
+	}
+}
+
+// Sphere queues the circles of the sphere along the XY, XZ and YZ planes.
+func (d *DebugDraw) Sphere(center mgl32.Vec3, radius float32, color mgl32.Vec3) {
+	for i := 0; i < debugDrawSphereSegments; i++ {
+		a0 := 2 * math.Pi * float32(i) / float32(debugDrawSphereSegments)
+		a1 := 2 * math.Pi * float32(i+1) / float32(debugDrawSphereSegments)
+		c0, s0 := cos(a0)*radius, sin(a0)*radius
+		c1, s1 := cos(a1)*radius, sin(a1)*radius
+		d.Line(center.Add(mgl32.Vec3{c0, s0, 0}), center.Add(mgl32.Vec3{c1, s1, 0}), color)
+		d.Line(center.Add(mgl32.Vec3{c0, 0, s0}), center.Add(mgl32.Vec3{c1, 0, s1}), color)
+		d.Line(center.Add(mgl32.Vec3{0, c0, s0}), center.Add(mgl32.Vec3{0, c1, s1}), color)
+	}
+}
+
+// DrawAxes draws the world axes at the origin, along with
+// any segments already queued.
+func (d *DebugDraw) DrawAxes(cameraController *CameraController, length float32) {
+	d.Axes(mgl32.Vec3{}, length)
+	d.Flush(cameraController)
+}
+
+// Clear drops the queued segments.
+func (d *DebugDraw) Clear() {
+	d.vertices = d.vertices[:0]
+}
+
+// Flush draws the queued segments in a single draw call, then clears them.
+func (d *DebugDraw) Flush(cameraController *CameraController) {
+	defer d.Clear()
+	if len(d.vertices) == 0 {
+		return
+	}
+	d.mesh.SetVertices(d.vertices)
+
+	vp := cameraController.GetViewProjectionMatrix()
+	d.shaderProgram.Bind()
+	d.shaderProgram.SetUniformMatrix4fv("vp", 1, false, &vp[0])
+	d.mesh.Bind()
+
+	d.mesh.draw()
+
+	d.mesh.Unbind()
+	d.shaderProgram.Unbind()
+}
+
+// DrawLine queues a debug segment from a to b, drawn by FlushDebugShapes.
+func (r *Renderer) DrawLine(a, b mgl32.Vec3, color mgl32.Vec3) {
+	r.debugDraw.Line(a, b, color)
+}
+
+// DrawTriangle queues the outline of the triangle abc,
+// drawn by FlushDebugShapes.
+func (r *Renderer) DrawTriangle(a, b, c mgl32.Vec3, color mgl32.Vec3) {
+	r.debugDraw.Triangle(a, b, c, color)
+}
+
+// DrawBox queues the edges of the axis-aligned box
+// from min to max, drawn by FlushDebugShapes.
+func (r *Renderer) DrawBox(min, max mgl32.Vec3, color mgl32.Vec3) {
+	r.debugDraw.Box(min, max, color)
+}
+
+// DrawSphere queues the outline of the sphere,
+// drawn by FlushDebugShapes.
+func (r *Renderer) DrawSphere(center mgl32.Vec3, radius float32, color mgl32.Vec3) {
+	r.debugDraw.Sphere(center, radius, color)
+}
+
+// FlushDebugShapes draws the shapes queued with DrawLine, DrawTriangle,
+// DrawBox and DrawSphere through the camera passed to the last Begin,
+// then clears them. The application calls it once per frame after
+// rendering the layers. Shapes queued before any Begin are dropped.
+func (r *Renderer) FlushDebugShapes() {
+	if r.cameraController == nil {
+		r.debugDraw.Clear()
+		return
+	}
+	r.debugDraw.Flush(r.cameraController)
+}
+
+// Delete .
+func (d *DebugDraw) Delete() {
+	d.mesh.Delete()
+	d.shaderProgram.Delete()
+}
@@ -0,0 +1,64 @@
+package renderer
+
+import (
+	"fmt"
+
+	"github.com/devodev/opengl-experiment/internal/opengl"
+)
+
+// depthFragmentShader converts window space depth back to a distance
+// from the camera, non-linear for perspective projections, and maps
+// it from near (black) to far (white).
+const depthFragmentShader = `
+#version 460 core
+layout (location = 0) out vec4 fragColor;
+
+in vec2 fragTexCoord;
+
+uniform sampler2D screenTexture;
+uniform float near;
+uniform float far;
+uniform bool perspective;
+
+void main() {
+    float depth = texture(screenTexture, fragTexCoord).r;
+    float distance = near + depth * (far - near);
+    if (perspective) {
+        float ndc = depth * 2.0 - 1.0;
+        distance = (2.0 * near * far) / (far + near - ndc * (far - near));
+    }
+    float gray = (distance - near) / (far - near);
+    fragColor = vec4(vec3(gray), 1.0);
+}
+    `
+
+// DrawDepth draws the depth buffer of framebuffer in grayscale over
+// the whole viewport, using the clip planes of the camera it was
+// rendered with. The framebuffer must be created with DepthTexture.
+func (r *Renderer) DrawDepth(framebuffer *opengl.Framebuffer, camera Camera) error {
+	depthTexture := framebuffer.GetDepthTexture()
+	if depthTexture == 0 {
+		return fmt.Errorf("framebuffer has no depth texture, create it with opengl.FramebufferOptions.DepthTexture")
+	}
+	if r.depthShaderProgram == nil {
+		shaderProgram, err := NewPostProcessShaderProgram(depthFragmentShader + "\x00")
+		if err != nil {
+			return err
+		}
+		r.depthShaderProgram = shaderProgram
+	}
+	near, far := camera.GetClipPlanes()
+	projection := camera.GetProjectionMatrix()
+
+	r.depthShaderProgram.Bind()
+	r.depthShaderProgram.SetUniform1f("near", near)
+	r.depthShaderProgram.SetUniform1f("far", far)
+	// perspective projections copy -z to w, orthographic ones keep w = 1
+	perspective := int32(0)
+	if projection[11] != 0 {
+		perspective = 1
+	}
+	r.depthShaderProgram.SetUniform1i("perspective", perspective)
+	r.DrawPostProcess(r.depthShaderProgram, depthTexture)
+	return nil
+}
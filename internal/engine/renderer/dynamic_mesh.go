@@ -0,0 +1,74 @@
+package renderer
+
+import (
+	"github.com/devodev/opengl-experiment/internal/opengl"
+)
+
+// DynamicMesh is a Mesh whose geometry is expected
+// to change, typically every frame. Buffers grow as needed
+// when updated with more data than they can hold.
+type DynamicMesh struct {
+	*Mesh
+}
+
+// NewDynamicMesh allocates buffers able to hold vertexCount
+// vertices of the layout and indexCount indices.
+func NewDynamicMesh(layout *opengl.VBOLayout, vertexCount, indexCount int) (*DynamicMesh, error) {
+	vbo, err := opengl.NewVBO(vertexCount * int(layout.GetStride()))
+	if err != nil {
+		return nil, err
+	}
+	vbo.SetLayout(layout)
+
+	ibo := opengl.NewIBO(indexCount)
+	ibo.SetIndices(nil)
+
+	vao := opengl.NewVAO()
+	vao.AddVBO(vbo)
+	vao.SetIBO(ibo)
+
+	mesh := &DynamicMesh{
+		Mesh: &Mesh{
+			vao:       vao,
+			vbo:       vbo,
+			ibo:       ibo,
+			primitive: defaultMeshPrimitive,
+		},
+	}
+	return mesh, nil
+}
+
+// NewDynamicMeshArrays allocates a buffer able to hold
+// vertexCount vertices of the layout, drawn in order without IBO.
+func NewDynamicMeshArrays(layout *opengl.VBOLayout, vertexCount int) (*DynamicMesh, error) {
+	vbo, err := opengl.NewVBO(vertexCount * int(layout.GetStride()))
+	if err != nil {
+		return nil, err
+	}
+	vbo.SetLayout(layout)
+
+	vao := opengl.NewVAO()
+	vao.AddVBO(vbo)
+
+	mesh := &DynamicMesh{
+		Mesh: &Mesh{
+			vao:       vao,
+			vbo:       vbo,
+			primitive: defaultMeshPrimitive,
+		},
+	}
+	return mesh, nil
+}
+
+// SetVertices .
+func (m *DynamicMesh) SetVertices(vertices []float32) {
+	m.vbo.SetVertices(vertices)
+	recordUpload(4 * len(vertices))
+	m.vertexCount = vertexCount(vertices, m.vbo.GetLayout())
+}
+
+// SetIndices must only be called on meshes created with an IBO.
+func (m *DynamicMesh) SetIndices(indices []uint32) {
+	m.ibo.SetIndices(indices)
+	recordUpload(4 * len(indices))
+}
@@ -0,0 +1,136 @@
+package renderer
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+
+	"github.com/devodev/opengl-experiment/internal/opengl"
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+var (
+	defaultFontGlyphSize = 8
+	defaultFontColumns   = 16
+	defaultFontFirstChar = rune(0x20)
+	defaultFontFallback  = '?'
+)
+
+// BitmapFont is a monospace font atlas: a grid of equally sized
+// glyphs laid out row by row from the top left corner,
+// the first one being firstChar.
+type BitmapFont struct {
+	texture     *opengl.Texture
+	glyphWidth  int
+	glyphHeight int
+	columns     int
+	firstChar   rune
+	glyphCount  int
+
+	// atlas size in pixels
+	width  int
+	height int
+}
+
+// NewBitmapFont loads a font atlas from an image file, glyphs
+// are read from the alpha channel.
+func NewBitmapFont(path string, glyphWidth, glyphHeight int, firstChar rune) (*BitmapFont, error) {
+	reader, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading font atlas %q: %s", path, err)
+	}
+	defer reader.Close()
+
+	img, _, err := image.Decode(reader)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding font atlas %q: %s", path, err)
+	}
+	return NewBitmapFontFromImage(img, glyphWidth, glyphHeight, firstChar)
+}
+
+// NewBitmapFontFromImage .
+func NewBitmapFontFromImage(img image.Image, glyphWidth, glyphHeight int, firstChar rune) (*BitmapFont, error) {
+	if glyphWidth <= 0 || glyphHeight <= 0 {
+		return nil, fmt.Errorf("invalid glyph size: %dx%d", glyphWidth, glyphHeight)
+	}
+	size := img.Bounds().Size()
+	columns := size.X / glyphWidth
+	rows := size.Y / glyphHeight
+	if columns == 0 || rows == 0 {
+		return nil, fmt.Errorf("font atlas %dx%d smaller than a glyph: %dx%d", size.X, size.Y, glyphWidth, glyphHeight)
+	}
+
+	// keep glyphs crisp when scaled
+	texture, err := opengl.NewTextureFromImage(img, 0, opengl.TextureOptions{
+		MinFilter: gl.NEAREST,
+		MagFilter: gl.NEAREST,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating font atlas texture: %s", err)
+	}
+	font := &BitmapFont{
+		texture:     texture,
+		glyphWidth:  glyphWidth,
+		glyphHeight: glyphHeight,
+		columns:     columns,
+		firstChar:   firstChar,
+		glyphCount:  columns * rows,
+		width:       size.X,
+		height:      size.Y,
+	}
+	return font, nil
+}
+
+// NewDefaultBitmapFont returns the built-in 8x8 font
+// covering printable ASCII characters.
+func NewDefaultBitmapFont() (*BitmapFont, error) {
+	rows := (len(font8x8) + defaultFontColumns - 1) / defaultFontColumns
+	img := image.NewNRGBA(image.Rect(0, 0, defaultFontColumns*defaultFontGlyphSize, rows*defaultFontGlyphSize))
+	for i, glyph := range font8x8 {
+		x0 := (i % defaultFontColumns) * defaultFontGlyphSize
+		y0 := (i / defaultFontColumns) * defaultFontGlyphSize
+		for y, bits := range glyph {
+			for x := 0; x < defaultFontGlyphSize; x++ {
+				if bits&(1<<uint(x)) != 0 {
+					img.SetNRGBA(x0+x, y0+y, color.NRGBA{255, 255, 255, 255})
+				}
+			}
+		}
+	}
+	return NewBitmapFontFromImage(img, defaultFontGlyphSize, defaultFontGlyphSize, defaultFontFirstChar)
+}
+
+// GetGlyphSize returns the size of a glyph in pixels.
+func (f *BitmapFont) GetGlyphSize() (int, int) {
+	return f.glyphWidth, f.glyphHeight
+}
+
+// GetTexture .
+func (f *BitmapFont) GetTexture() *opengl.Texture {
+	return f.texture
+}
+
+// HasGlyph .
+func (f *BitmapFont) HasGlyph(r rune) bool {
+	index := int(r - f.firstChar)
+	return index >= 0 && index < f.glyphCount
+}
+
+// glyphTexCoords returns the bottom left and top right texture
+// coordinates of the glyph, images being flipped on upload.
+func (f *BitmapFont) glyphTexCoords(r rune) (u0, v0, u1, v1 float32) {
+	index := int(r - f.firstChar)
+	x := (index % f.columns) * f.glyphWidth
+	y := (index / f.columns) * f.glyphHeight
+	u0 = float32(x) / float32(f.width)
+	u1 = float32(x+f.glyphWidth) / float32(f.width)
+	v0 = 1 - float32(y+f.glyphHeight)/float32(f.height)
+	v1 = 1 - float32(y)/float32(f.height)
+	return
+}
+
+// Delete .
+func (f *BitmapFont) Delete() {
+	f.texture.Delete()
+}
@@ -0,0 +1,138 @@
+package renderer
+
+import (
+	"github.com/devodev/opengl-experiment/internal/opengl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+var (
+	defaultInfiniteGridCellSize     = float32(1)
+	defaultInfiniteGridColor        = mgl32.Vec4{0.6, 0.6, 0.6, 1}
+	defaultInfiniteGridFadeDistance = float32(20)
+)
+
+// The infinite grid shaders unproject the fullscreen quad to a ray per
+// fragment and intersect it with the XZ plane, where grid lines are
+// drawn with a constant width in pixels.
+const (
+	infiniteGridVertexShader = `
+#version 460 core
+layout (location = 0) in vec2 position;
+
+out vec3 nearPoint;
+out vec3 farPoint;
+
+uniform mat4 inverseVP;
+
+vec3 unproject(vec3 point) {
+    vec4 world = inverseVP * vec4(point, 1.0);
+    return world.xyz / world.w;
+}
+
+void main() {
+    nearPoint = unproject(vec3(position, -1.0));
+    farPoint = unproject(vec3(position, 1.0));
+    gl_Position = vec4(position, 0.0, 1.0);
+}
+    `
+
+	infiniteGridFragmentShader = `
+#version 460 core
+layout (location = 0) out vec4 fragColor;
+
+in vec3 nearPoint;
+in vec3 farPoint;
+
+uniform mat4 vp;
+uniform vec3 cameraPosition;
+uniform float cellSize;
+uniform vec4 gridColor;
+uniform float fadeDistance;
+
+void main() {
+    float dy = farPoint.y - nearPoint.y;
+    float t = dy != 0.0 ? -nearPoint.y / dy : -1.0;
+    if (t <= 0.0) {
+        discard;
+    }
+    vec3 position = nearPoint + t * (farPoint - nearPoint);
+
+    vec2 coord = position.xz / cellSize;
+    vec2 grid = abs(fract(coord - 0.5) - 0.5) / fwidth(coord);
+    float alpha = 1.0 - min(min(grid.x, grid.y), 1.0);
+    alpha *= 1.0 - smoothstep(fadeDistance * 0.5, fadeDistance, distance(position, cameraPosition));
+    if (alpha <= 0.0) {
+        discard;
+    }
+
+    // write the depth of the plane so that geometry occludes it
+    vec4 clip = vp * vec4(position, 1.0);
+    gl_FragDepth = (clip.z / clip.w) * 0.5 + 0.5;
+    fragColor = vec4(gridColor.rgb, gridColor.a * alpha);
+}
+    `
+)
+
+// InfiniteGrid draws a grid on the XZ plane extending to the
+// horizon, fading out with the distance to the camera. Unlike
+// NewGrid it needs no geometry besides a fullscreen quad.
+type InfiniteGrid struct {
+	shaderProgram *opengl.ShaderProgram
+	cellSize      float32
+	color         mgl32.Vec4
+	fadeDistance  float32
+}
+
+// NewInfiniteGrid .
+func NewInfiniteGrid() (*InfiniteGrid, error) {
+	shaderProgram, err := opengl.NewShaderProgram(infiniteGridVertexShader+"\x00", infiniteGridFragmentShader+"\x00")
+	if err != nil {
+		return nil, err
+	}
+	g := &InfiniteGrid{
+		shaderProgram: shaderProgram,
+		cellSize:      defaultInfiniteGridCellSize,
+		color:         defaultInfiniteGridColor,
+		fadeDistance:  defaultInfiniteGridFadeDistance,
+	}
+	return g, nil
+}
+
+// SetCellSize sets the distance between grid lines, in world units.
+func (g *InfiniteGrid) SetCellSize(size float32) {
+	g.cellSize = size
+}
+
+// SetColor .
+func (g *InfiniteGrid) SetColor(color mgl32.Vec4) {
+	g.color = color
+}
+
+// SetFadeDistance sets the distance to the camera
+// past which the grid is no longer visible.
+func (g *InfiniteGrid) SetFadeDistance(distance float32) {
+	g.fadeDistance = distance
+}
+
+// Draw draws the grid with the depth of the plane, it is
+// occluded by geometry when the depth test is enabled.
+// Blending must be enabled for the fading to show.
+func (g *InfiniteGrid) Draw(r *Renderer, cameraController *CameraController) {
+	vp := cameraController.GetViewProjectionMatrix()
+	inverseVP := vp.Inv()
+	cameraPosition := cameraController.GetPosition()
+
+	g.shaderProgram.Bind()
+	g.shaderProgram.SetUniformMatrix4fv("vp", 1, false, &vp[0])
+	g.shaderProgram.SetUniformMatrix4fv("inverseVP", 1, false, &inverseVP[0])
+	g.shaderProgram.SetUniform3f("cameraPosition", cameraPosition[0], cameraPosition[1], cameraPosition[2])
+	g.shaderProgram.SetUniform1f("cellSize", g.cellSize)
+	g.shaderProgram.SetUniform4f("gridColor", g.color[0], g.color[1], g.color[2], g.color[3])
+	g.shaderProgram.SetUniform1f("fadeDistance", g.fadeDistance)
+	r.DrawMesh(r.fullscreenQuad, g.shaderProgram)
+}
+
+// Delete .
+func (g *InfiniteGrid) Delete() {
+	g.shaderProgram.Delete()
+}
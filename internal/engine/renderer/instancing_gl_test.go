@@ -0,0 +1,52 @@
+//go:build gltest
+// +build gltest
+
+package renderer
+
+import (
+	"testing"
+
+	"github.com/devodev/opengl-experiment/internal/gltest"
+	"github.com/devodev/opengl-experiment/internal/opengl"
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+const benchmarkInstances = 1000
+
+func BenchmarkDrawMeshInstanced(b *testing.B) {
+	gltest.Context(b)
+
+	program, err := opengl.NewShaderProgram(solidVertexShader, solidFragmentShader)
+	if err != nil {
+		b.Fatalf("error creating shader program: %s", err)
+	}
+	defer program.Delete()
+	mesh, err := NewMesh(testQuadVertices, testQuadIndices, newTestLayout())
+	if err != nil {
+		b.Fatalf("error creating mesh: %s", err)
+	}
+	defer mesh.Delete()
+
+	program.Bind()
+	mesh.Bind()
+	defer func() {
+		mesh.Unbind()
+		program.Unbind()
+	}()
+
+	// gl.Finish waits for the GPU so that its work is measured too
+	b.Run("separate draws", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < benchmarkInstances; j++ {
+				mesh.draw()
+			}
+			gl.Finish()
+		}
+	})
+	b.Run("instanced draw", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			mesh.drawInstanced(benchmarkInstances)
+			gl.Finish()
+		}
+	})
+}
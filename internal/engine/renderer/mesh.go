@@ -0,0 +1,307 @@
+package renderer
+
+import (
+	"fmt"
+
+	"github.com/devodev/opengl-experiment/internal/opengl"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+var (
+	defaultMeshPrimitive = uint32(gl.TRIANGLES)
+)
+
+// Mesh owns the VAO, VBO and IBO used to draw
+// a piece of static geometry. Meshes without an IBO
+// are drawn using the vertices in order.
+type Mesh struct {
+	vao         *opengl.VAO
+	vbo         *opengl.VBO
+	ibo         *opengl.IBO
+	vertexCount int32
+	primitive   uint32
+	// local bounds of the positions, nil when unknown
+	// such as for dynamic meshes
+	bounds *AABB
+}
+
+// NewMesh .
+func NewMesh(vertices []float32, indices []uint32, layout *opengl.VBOLayout) (*Mesh, error) {
+	count, err := validateMeshVertices(vertices, layout)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMeshIndices(indices, count); err != nil {
+		return nil, err
+	}
+	return newIndexedMesh(vertices, layout, func() (*opengl.IBO, error) {
+		return opengl.NewStaticIBO(indices)
+	})
+}
+
+// NewMesh16 is like NewMesh using 16 bits indices, which
+// halves the index memory of meshes of at most 65536 vertices.
+func NewMesh16(vertices []float32, indices []uint16, layout *opengl.VBOLayout) (*Mesh, error) {
+	count, err := validateMeshVertices(vertices, layout)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMeshIndices16(indices, count); err != nil {
+		return nil, err
+	}
+	return newIndexedMesh(vertices, layout, func() (*opengl.IBO, error) {
+		return opengl.NewStaticIBO16(indices)
+	})
+}
+
+func newIndexedMesh(vertices []float32, layout *opengl.VBOLayout, newIBO func() (*opengl.IBO, error)) (*Mesh, error) {
+	vbo, err := opengl.NewStaticVBO(vertices)
+	if err != nil {
+		return nil, err
+	}
+	vbo.SetLayout(layout)
+
+	ibo, err := newIBO()
+	if err != nil {
+		vbo.Delete()
+		return nil, err
+	}
+
+	recordUpload(4*len(vertices) + indexBytes(ibo))
+
+	vao := opengl.NewVAO()
+	vao.AddVBO(vbo)
+	vao.SetIBO(ibo)
+
+	mesh := &Mesh{
+		vao:         vao,
+		vbo:         vbo,
+		ibo:         ibo,
+		vertexCount: vertexCount(vertices, layout),
+		primitive:   defaultMeshPrimitive,
+		bounds:      computeAABB(vertices, layout),
+	}
+	return mesh, nil
+}
+
+// NewMeshArrays creates a mesh without IBO.
+func NewMeshArrays(vertices []float32, layout *opengl.VBOLayout) (*Mesh, error) {
+	if _, err := validateMeshVertices(vertices, layout); err != nil {
+		return nil, err
+	}
+	vbo, err := opengl.NewStaticVBO(vertices)
+	if err != nil {
+		return nil, err
+	}
+	vbo.SetLayout(layout)
+	recordUpload(4 * len(vertices))
+
+	vao := opengl.NewVAO()
+	vao.AddVBO(vbo)
+
+	mesh := &Mesh{
+		vao:         vao,
+		vbo:         vbo,
+		vertexCount: vertexCount(vertices, layout),
+		primitive:   defaultMeshPrimitive,
+		bounds:      computeAABB(vertices, layout),
+	}
+	return mesh, nil
+}
+
+// validateMeshVertices checks that vertices hold a whole number
+// of vertices of the layout and returns that number. Indices must
+// then only reference them, the GPU would otherwise silently
+// read garbage.
+func validateMeshVertices(vertices []float32, layout *opengl.VBOLayout) (uint32, error) {
+	stride := int(layout.GetStride())
+	if stride == 0 || stride%4 != 0 {
+		return 0, fmt.Errorf("invalid mesh layout stride: %d", stride)
+	}
+	floatsPerVertex := stride / 4
+	if len(vertices)%floatsPerVertex != 0 {
+		return 0, fmt.Errorf("vertices length %d is not a multiple of the layout size: %d", len(vertices), floatsPerVertex)
+	}
+	return uint32(len(vertices) / floatsPerVertex), nil
+}
+
+// validateMeshIndices checks that indices only reference
+// the count vertices returned by validateMeshVertices.
+func validateMeshIndices(indices []uint32, count uint32) error {
+	for i, index := range indices {
+		if index >= count {
+			return fmt.Errorf("index %d out of bounds at position %d: %d vertices", index, i, count)
+		}
+	}
+	return nil
+}
+
+// validateMeshIndices16 is like validateMeshIndices for 16 bits indices.
+func validateMeshIndices16(indices []uint16, count uint32) error {
+	for i, index := range indices {
+		if uint32(index) >= count {
+			return fmt.Errorf("index %d out of bounds at position %d: %d vertices", index, i, count)
+		}
+	}
+	return nil
+}
+
+// checkDrawState prints the error of opengl.CheckDrawState, if any.
+func checkDrawState(context string) {
+	if err := opengl.CheckDrawState(context); err != nil {
+		fmt.Printf("[OpenGL WARNING] %s\n", err)
+	}
+}
+
+// indexBytes returns the size of the indices held by ibo.
+func indexBytes(ibo *opengl.IBO) int {
+	if ibo.GetIndexType() == gl.UNSIGNED_SHORT {
+		return 2 * int(ibo.GetCount())
+	}
+	return 4 * int(ibo.GetCount())
+}
+
+func vertexCount(vertices []float32, layout *opengl.VBOLayout) int32 {
+	return int32(4*len(vertices)) / layout.GetStride()
+}
+
+// SetPrimitive sets how vertices are assembled when drawn,
+// gl.TRIANGLES by default.
+func (m *Mesh) SetPrimitive(primitive uint32) error {
+	switch primitive {
+	case gl.POINTS,
+		gl.LINES, gl.LINE_STRIP, gl.LINE_LOOP,
+		gl.TRIANGLES, gl.TRIANGLE_STRIP, gl.TRIANGLE_FAN:
+	default:
+		return fmt.Errorf("unsupported mesh primitive: 0x%x", primitive)
+	}
+	m.primitive = primitive
+	return nil
+}
+
+// GetPrimitive .
+func (m *Mesh) GetPrimitive() uint32 {
+	return m.primitive
+}
+
+// UpdateVertices replaces the vertex values in place, ex: for
+// animated geometry whose vertex count does not change. vertices
+// must be the size of the original allocation, geometry changing
+// size needs a DynamicMesh.
+func (m *Mesh) UpdateVertices(vertices []float32) error {
+	if size := 4 * len(vertices); size != m.vbo.GetSize() {
+		return fmt.Errorf("vertices size %d bytes does not match the mesh allocation of %d bytes, use a DynamicMesh to resize geometry", size, m.vbo.GetSize())
+	}
+	// the VBO is bound and updated with BufferSubData
+	// as the size fits the allocation
+	m.vbo.SetVertices(vertices)
+	recordUpload(4 * len(vertices))
+	if m.bounds != nil {
+		m.bounds = computeAABB(vertices, m.vbo.GetLayout())
+	}
+	return nil
+}
+
+// BoundingBox returns the local bounds of the positions, read from the
+// first attribute of the layout when the mesh is created. Both are
+// zero when the bounds are unknown, see HasBoundingBox.
+func (m *Mesh) BoundingBox() (mgl32.Vec3, mgl32.Vec3) {
+	if m.bounds == nil {
+		return mgl32.Vec3{}, mgl32.Vec3{}
+	}
+	return m.bounds.Min, m.bounds.Max
+}
+
+// HasBoundingBox reports whether the bounds are known, they are not
+// for dynamic meshes or when the first attribute is not 2 or 3 floats.
+func (m *Mesh) HasBoundingBox() bool {
+	return m.bounds != nil
+}
+
+// GetBoundingCenter .
+func (m *Mesh) GetBoundingCenter() mgl32.Vec3 {
+	if m.bounds == nil {
+		return mgl32.Vec3{}
+	}
+	return m.bounds.Center()
+}
+
+// GetBoundingRadius returns the radius of the sphere
+// centered on the bounding box and enclosing it.
+func (m *Mesh) GetBoundingRadius() float32 {
+	if m.bounds == nil {
+		return 0
+	}
+	return m.bounds.Radius()
+}
+
+// AddVBO attaches an additional VBO to the mesh, typically
+// holding per-instance data. Its attributes follow the
+// mesh attributes and the mesh does not take ownership of it.
+func (m *Mesh) AddVBO(vbo *opengl.VBO) {
+	m.vao.AddVBO(vbo)
+}
+
+// draw issues the draw call, the mesh and
+// the shader program must be bound.
+func (m *Mesh) draw() {
+	checkDrawState("mesh draw")
+	recordDraw(m.primitive, m.GetCount(), 1)
+	if m.IsIndexed() {
+		gl.DrawElements(m.primitive, m.GetCount(), m.ibo.GetIndexType(), nil)
+	} else {
+		gl.DrawArrays(m.primitive, 0, m.GetCount())
+	}
+}
+
+// drawInstanced issues the draw call, the mesh and
+// the shader program must be bound.
+func (m *Mesh) drawInstanced(instances int32) {
+	checkDrawState("mesh instanced draw")
+	recordDraw(m.primitive, m.GetCount(), instances)
+	if m.IsIndexed() {
+		gl.DrawElementsInstanced(m.primitive, m.GetCount(), m.ibo.GetIndexType(), nil, instances)
+	} else {
+		gl.DrawArraysInstanced(m.primitive, 0, m.GetCount(), instances)
+	}
+}
+
+// Bind .
+func (m *Mesh) Bind() {
+	m.vao.Bind()
+}
+
+// Unbind .
+func (m *Mesh) Unbind() {
+	m.vao.Unbind()
+}
+
+// IsIndexed .
+func (m *Mesh) IsIndexed() bool {
+	return m.ibo != nil
+}
+
+// GetCount returns the number of indices, or
+// vertices when the mesh is not indexed.
+func (m *Mesh) GetCount() int32 {
+	if !m.IsIndexed() {
+		return m.vertexCount
+	}
+	return m.ibo.GetCount()
+}
+
+// GetVertexCount .
+func (m *Mesh) GetVertexCount() int32 {
+	return m.vertexCount
+}
+
+// Delete frees the GPU memory held by the mesh.
+// The mesh must not be used afterwards.
+func (m *Mesh) Delete() {
+	m.vao.Delete()
+	m.vbo.Delete()
+	if m.IsIndexed() {
+		m.ibo.Delete()
+	}
+}
@@ -0,0 +1,127 @@
+//go:build gltest
+// +build gltest
+
+package renderer
+
+import (
+	"testing"
+
+	"github.com/devodev/opengl-experiment/internal/gltest"
+	"github.com/devodev/opengl-experiment/internal/opengl"
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+const (
+	solidVertexShader = `
+#version 460 core
+layout (location = 0) in vec3 position;
+
+void main() {
+    gl_Position = vec4(position, 1.0);
+}
+` + "\x00"
+
+	solidFragmentShader = `
+#version 460 core
+layout (location = 0) out vec4 fragColor;
+
+void main() {
+    fragColor = vec4(1.0, 0.0, 0.0, 1.0);
+}
+` + "\x00"
+)
+
+// fullscreen quad in normalized device coordinates
+var (
+	testQuadVertices = []float32{
+		-1, -1, 0,
+		1, -1, 0,
+		1, 1, 0,
+		-1, 1, 0,
+	}
+	testQuadIndices = []uint32{0, 1, 2, 0, 2, 3}
+)
+
+// drawCenterPixel draws the mesh in red over a black framebuffer
+// and returns the color of its center pixel.
+func drawCenterPixel(t *testing.T, mesh *Mesh) [4]uint8 {
+	t.Helper()
+	program, err := opengl.NewShaderProgram(solidVertexShader, solidFragmentShader)
+	if err != nil {
+		t.Fatalf("error creating shader program: %s", err)
+	}
+	defer program.Delete()
+	fbo, err := opengl.NewFramebuffer(8, 8)
+	if err != nil {
+		t.Fatalf("error creating framebuffer: %s", err)
+	}
+	defer fbo.Delete()
+
+	fbo.Bind()
+	defer fbo.Unbind()
+	gl.ClearColor(0, 0, 0, 1)
+	gl.Clear(gl.COLOR_BUFFER_BIT)
+
+	program.Bind()
+	mesh.Bind()
+	mesh.draw()
+	mesh.Unbind()
+	program.Unbind()
+
+	var pixel [4]uint8
+	gl.ReadPixels(4, 4, 1, 1, gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(&pixel[0]))
+	return pixel
+}
+
+func TestDrawMesh(t *testing.T) {
+	gltest.Context(t)
+
+	var arrayVertices []float32
+	for _, index := range testQuadIndices {
+		arrayVertices = append(arrayVertices, testQuadVertices[3*index:3*index+3]...)
+	}
+	cases := []struct {
+		name    string
+		mesh    func() (*Mesh, error)
+		indexed bool
+	}{
+		{"DrawElements", func() (*Mesh, error) { return NewMesh(testQuadVertices, testQuadIndices, newTestLayout()) }, true},
+		{"DrawArrays", func() (*Mesh, error) { return NewMeshArrays(arrayVertices, newTestLayout()) }, false},
+	}
+	for _, c := range cases {
+		mesh, err := c.mesh()
+		if err != nil {
+			t.Fatalf("%s: error creating mesh: %s", c.name, err)
+		}
+		if mesh.IsIndexed() != c.indexed {
+			t.Errorf("%s: IsIndexed() = %v, want %v", c.name, mesh.IsIndexed(), c.indexed)
+		}
+		if mesh.GetCount() != 6 {
+			t.Errorf("%s: GetCount() = %d, want 6", c.name, mesh.GetCount())
+		}
+		if pixel := drawCenterPixel(t, mesh); pixel != [4]uint8{255, 0, 0, 255} {
+			t.Errorf("%s: center pixel = %v, want red", c.name, pixel)
+		}
+		mesh.Delete()
+	}
+}
+
+func TestDrawMesh16(t *testing.T) {
+	gltest.Context(t)
+
+	indices := make([]uint16, len(testQuadIndices))
+	for i, index := range testQuadIndices {
+		indices[i] = uint16(index)
+	}
+	mesh, err := NewMesh16(testQuadVertices, indices, newTestLayout())
+	if err != nil {
+		t.Fatalf("error creating mesh: %s", err)
+	}
+	defer mesh.Delete()
+	if got := mesh.ibo.GetIndexType(); got != gl.UNSIGNED_SHORT {
+		t.Errorf("GetIndexType() = 0x%x, want GL_UNSIGNED_SHORT", got)
+	}
+	if pixel := drawCenterPixel(t, mesh); pixel != [4]uint8{255, 0, 0, 255} {
+		t.Errorf("center pixel = %v, want red", pixel)
+	}
+}
@@ -0,0 +1,80 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/devodev/opengl-experiment/internal/opengl"
+)
+
+// newTestLayout returns a layout of 3 floats per vertex.
+func newTestLayout() *opengl.VBOLayout {
+	return opengl.NewVBOLayout(
+		opengl.VBOLayoutElement{Count: 3, Normalized: false, DataType: opengl.GLDataTypeFloat},
+	)
+}
+
+func TestValidateMeshVertices(t *testing.T) {
+	cases := []struct {
+		name      string
+		vertices  []float32
+		wantCount uint32
+		wantErr   bool
+	}{
+		{"whole vertices", []float32{0, 0, 0, 1, 1, 1}, 2, false},
+		{"empty", []float32{}, 0, false},
+		{"ragged", []float32{0, 0, 0, 1, 1}, 0, true},
+	}
+	for _, c := range cases {
+		count, err := validateMeshVertices(c.vertices, newTestLayout())
+		if (err != nil) != c.wantErr {
+			t.Errorf("%s: validateMeshVertices() error = %v, want error %v", c.name, err, c.wantErr)
+			continue
+		}
+		if count != c.wantCount {
+			t.Errorf("%s: validateMeshVertices() = %d, want %d", c.name, count, c.wantCount)
+		}
+	}
+}
+
+func TestValidateMeshIndices(t *testing.T) {
+	cases := []struct {
+		name    string
+		indices []uint32
+		wantErr bool
+	}{
+		{"in bounds", []uint32{0, 1, 2}, false},
+		{"last vertex", []uint32{2}, false},
+		{"too large", []uint32{0, 1, 3}, true},
+	}
+	for _, c := range cases {
+		if err := validateMeshIndices(c.indices, 3); (err != nil) != c.wantErr {
+			t.Errorf("%s: validateMeshIndices() error = %v, want error %v", c.name, err, c.wantErr)
+		}
+		indices16 := make([]uint16, len(c.indices))
+		for i, index := range c.indices {
+			indices16[i] = uint16(index)
+		}
+		if err := validateMeshIndices16(indices16, 3); (err != nil) != c.wantErr {
+			t.Errorf("%s: validateMeshIndices16() error = %v, want error %v", c.name, err, c.wantErr)
+		}
+	}
+}
+
+func TestVertexCount(t *testing.T) {
+	layout := opengl.NewVBOLayout(
+		opengl.VBOLayoutElement{Count: 3, Normalized: false, DataType: opengl.GLDataTypeFloat},
+		opengl.VBOLayoutElement{Count: 2, Normalized: false, DataType: opengl.GLDataTypeFloat},
+	)
+	vertices := make([]float32, 4*5)
+	if got := vertexCount(vertices, layout); got != 4 {
+		t.Errorf("vertexCount() = %d, want 4", got)
+	}
+	// a mesh without IBO is drawn with DrawArrays over its vertices
+	mesh := &Mesh{vertexCount: vertexCount(vertices, layout)}
+	if mesh.IsIndexed() {
+		t.Errorf("IsIndexed() = true for a mesh without IBO")
+	}
+	if got := mesh.GetCount(); got != 4 {
+		t.Errorf("GetCount() = %d, want 4", got)
+	}
+}
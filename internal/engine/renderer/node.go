@@ -0,0 +1,128 @@
+package renderer
+
+import (
+	"fmt"
+
+	"github.com/devodev/opengl-experiment/internal/opengl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Node is an element of a scene graph. Its transform is
+// relative to its parent, if any.
+type Node struct {
+	transform     *Transform
+	mesh          *Mesh
+	shaderProgram *opengl.ShaderProgram
+
+	parent   *Node
+	children []*Node
+}
+
+// NewNode returns an empty node, used as is to group children.
+func NewNode() *Node {
+	return &Node{transform: NewTransform()}
+}
+
+// NewMeshNode .
+func NewMeshNode(mesh *Mesh, shaderProgram *opengl.ShaderProgram) *Node {
+	node := NewNode()
+	node.SetMesh(mesh, shaderProgram)
+	return node
+}
+
+// GetTransform .
+func (n *Node) GetTransform() *Transform {
+	return n.transform
+}
+
+// SetMesh sets the mesh drawn by the node, using the
+// "model" and "vp" uniforms of the shader program.
+func (n *Node) SetMesh(mesh *Mesh, shaderProgram *opengl.ShaderProgram) {
+	n.mesh = mesh
+	n.shaderProgram = shaderProgram
+}
+
+// GetMesh .
+func (n *Node) GetMesh() *Mesh {
+	return n.mesh
+}
+
+// GetParent .
+func (n *Node) GetParent() *Node {
+	return n.parent
+}
+
+// GetChildren .
+func (n *Node) GetChildren() []*Node {
+	return n.children
+}
+
+// AddChild moves child under n, detaching it from its
+// previous parent. It fails if child is n or one of its ancestors.
+func (n *Node) AddChild(child *Node) error {
+	for ancestor := n; ancestor != nil; ancestor = ancestor.parent {
+		if ancestor == child {
+			return fmt.Errorf("adding child would create a cycle in the scene graph")
+		}
+	}
+	if child.parent != nil {
+		child.parent.RemoveChild(child)
+	}
+	child.parent = n
+	n.children = append(n.children, child)
+	return nil
+}
+
+// RemoveChild .
+func (n *Node) RemoveChild(child *Node) {
+	for idx, c := range n.children {
+		if c == child {
+			n.children = append(n.children[:idx], n.children[idx+1:]...)
+			child.parent = nil
+			return
+		}
+	}
+}
+
+// GetWorldMatrix returns the transform of the node
+// combined with the ones of its ancestors.
+func (n *Node) GetWorldMatrix() mgl32.Mat4 {
+	world := n.transform.GetMatrix()
+	for ancestor := n.parent; ancestor != nil; ancestor = ancestor.parent {
+		world = ancestor.transform.GetMatrix().Mul4(world)
+	}
+	return world
+}
+
+// Draw draws the node and its descendants from the point of view
+// of the camera. Meshes whose bounds are outside the view are skipped,
+// the children of their node are still considered.
+func (n *Node) Draw(r *Renderer, cameraController *CameraController) {
+	parentWorld := mgl32.Ident4()
+	if n.parent != nil {
+		parentWorld = n.parent.GetWorldMatrix()
+	}
+	vp := cameraController.GetViewProjectionMatrix()
+	n.draw(r, vp, NewFrustum(vp), parentWorld)
+}
+
+func (n *Node) draw(r *Renderer, vp mgl32.Mat4, frustum *Frustum, parentWorld mgl32.Mat4) {
+	world := parentWorld.Mul4(n.transform.GetMatrix())
+	if n.mesh != nil && n.shaderProgram != nil && n.isVisible(frustum, world) {
+		n.shaderProgram.Bind()
+		n.shaderProgram.SetUniformMatrix4fv("vp", 1, false, &vp[0])
+		r.DrawMeshWithTransform(n.mesh, n.shaderProgram, world)
+	}
+	for _, child := range n.children {
+		child.draw(r, vp, frustum, world)
+	}
+}
+
+// isVisible reports whether the bounds of the mesh, when known,
+// intersect the frustum once transformed to world space.
+func (n *Node) isVisible(frustum *Frustum, world mgl32.Mat4) bool {
+	if n.mesh.bounds == nil {
+		return true
+	}
+	return n.mesh.bounds.Transform(world).Intersects(frustum)
+}
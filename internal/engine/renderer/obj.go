@@ -0,0 +1,202 @@
+package renderer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// objVertex references a position, texture coordinate and
+// normal, 0 based, -1 when missing.
+type objVertex struct {
+	position int
+	texCoord int
+	normal   int
+}
+
+// objData holds the parsed attributes along with the
+// vertices and indices using NewPrimitiveLayout.
+type objData struct {
+	positions []mgl32.Vec3
+	texCoords []mgl32.Vec2
+	normals   []mgl32.Vec3
+
+	vertices []float32
+	indices  []uint32
+	// combined attributes already added to vertices
+	combined map[objVertex]uint32
+}
+
+// LoadOBJ loads a Wavefront OBJ model into a mesh using
+// NewPrimitiveLayout. Faces with more than three vertices are
+// triangulated as fans and missing texture coordinates or normals
+// are set to zero. Materials, groups and objects are ignored.
+func LoadOBJ(path string) (*Mesh, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading OBJ file %q: %s", path, err)
+	}
+	defer f.Close()
+
+	data, err := parseOBJ(f, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data.indices) == 0 {
+		return nil, fmt.Errorf("OBJ file %q has no faces", path)
+	}
+	return NewMesh(data.vertices, data.indices, NewPrimitiveLayout())
+}
+
+func parseOBJ(r io.Reader, name string) (*objData, error) {
+	data := &objData{
+		combined: make(map[objVertex]uint32),
+	}
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		var err error
+		switch fields[0] {
+		case "v":
+			var v []float32
+			if v, err = parseOBJFloats(fields[1:], 3); err == nil {
+				data.positions = append(data.positions, mgl32.Vec3{v[0], v[1], v[2]})
+			}
+		case "vt":
+			var v []float32
+			if v, err = parseOBJFloats(fields[1:], 2); err == nil {
+				data.texCoords = append(data.texCoords, mgl32.Vec2{v[0], v[1]})
+			}
+		case "vn":
+			var v []float32
+			if v, err = parseOBJFloats(fields[1:], 3); err == nil {
+				data.normals = append(data.normals, mgl32.Vec3{v[0], v[1], v[2]})
+			}
+		case "f":
+			err = data.addFace(fields[1:])
+		default:
+			// mtllib, usemtl, o, g, s and others are not supported
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %s", name, lineNumber, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading OBJ file %q: %s", name, err)
+	}
+	return data, nil
+}
+
+// parseOBJFloats parses at least count floats, extra
+// components such as w are ignored.
+func parseOBJFloats(fields []string, count int) ([]float32, error) {
+	if len(fields) < count {
+		return nil, fmt.Errorf("expected %d values, got %d", count, len(fields))
+	}
+	values := make([]float32, count)
+	for i := 0; i < count; i++ {
+		v, err := strconv.ParseFloat(fields[i], 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q", fields[i])
+		}
+		values[i] = float32(v)
+	}
+	return values, nil
+}
+
+func (d *objData) addFace(fields []string) error {
+	if len(fields) < 3 {
+		return fmt.Errorf("face needs at least 3 vertices, got %d", len(fields))
+	}
+	indices := make([]uint32, len(fields))
+	for i, field := range fields {
+		vertex, err := d.parseFaceVertex(field)
+		if err != nil {
+			return err
+		}
+		indices[i] = d.addVertex(vertex)
+	}
+	// triangulate as a fan around the first vertex
+	for i := 1; i < len(indices)-1; i++ {
+		d.indices = append(d.indices, indices[0], indices[i], indices[i+1])
+	}
+	return nil
+}
+
+// parseFaceVertex parses v, v/vt, v//vn or v/vt/vn.
+func (d *objData) parseFaceVertex(field string) (objVertex, error) {
+	vertex := objVertex{position: -1, texCoord: -1, normal: -1}
+	parts := strings.Split(field, "/")
+	if len(parts) > 3 {
+		return vertex, fmt.Errorf("invalid face vertex %q", field)
+	}
+	var err error
+	if vertex.position, err = parseOBJIndex(parts[0], len(d.positions)); err != nil {
+		return vertex, fmt.Errorf("invalid position in face vertex %q: %s", field, err)
+	}
+	if len(parts) > 1 && parts[1] != "" {
+		if vertex.texCoord, err = parseOBJIndex(parts[1], len(d.texCoords)); err != nil {
+			return vertex, fmt.Errorf("invalid texture coordinate in face vertex %q: %s", field, err)
+		}
+	}
+	if len(parts) > 2 && parts[2] != "" {
+		if vertex.normal, err = parseOBJIndex(parts[2], len(d.normals)); err != nil {
+			return vertex, fmt.Errorf("invalid normal in face vertex %q: %s", field, err)
+		}
+	}
+	return vertex, nil
+}
+
+// parseOBJIndex converts a 1 based index, or a negative
+// one relative to the end, to a 0 based index.
+func parseOBJIndex(s string, count int) (int, error) {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("not an index: %q", s)
+	}
+	if i < 0 {
+		i += count
+	} else {
+		i--
+	}
+	if i < 0 || i >= count {
+		return 0, fmt.Errorf("index %s out of bounds: %d defined", s, count)
+	}
+	return i, nil
+}
+
+// addVertex returns the index of the combined vertex,
+// appending it to the vertices the first time.
+func (d *objData) addVertex(vertex objVertex) uint32 {
+	if index, ok := d.combined[vertex]; ok {
+		return index
+	}
+	var normal mgl32.Vec3
+	if vertex.normal >= 0 {
+		normal = d.normals[vertex.normal]
+	}
+	var texCoord mgl32.Vec2
+	if vertex.texCoord >= 0 {
+		texCoord = d.texCoords[vertex.texCoord]
+	}
+	position := d.positions[vertex.position]
+
+	index := uint32(len(d.combined))
+	d.vertices = append(d.vertices,
+		position[0], position[1], position[2],
+		normal[0], normal[1], normal[2],
+		texCoord[0], texCoord[1],
+	)
+	d.combined[vertex] = index
+	return index
+}
@@ -0,0 +1,74 @@
+package renderer
+
+import "image/color"
+
+// Option .
+type Option func(*Renderer) error
+
+// WithDebugNotificationsOption enables reporting of
+// notification severity OpenGL debug messages.
+func WithDebugNotificationsOption(enabled bool) Option {
+	return func(r *Renderer) error {
+		r.debugNotifications = enabled
+		return nil
+	}
+}
+
+// WithDebugChecksOption validates the VAO and shader program
+// bound before each draw call, see opengl.CheckDrawState.
+func WithDebugChecksOption(enabled bool) Option {
+	return func(r *Renderer) error {
+		r.debugChecks = enabled
+		return nil
+	}
+}
+
+// WithBackgroundColorOption .
+func WithBackgroundColorOption(c color.RGBA) Option {
+	return func(r *Renderer) error {
+		r.backgroundColor = c
+		return nil
+	}
+}
+
+// WithDepthTestOption sets whether depth testing
+// is enabled, it is by default.
+func WithDepthTestOption(enabled bool) Option {
+	return func(r *Renderer) error {
+		r.depthTest = enabled
+		return nil
+	}
+}
+
+// WithFaceCullingOption culls back faces
+// with a counter-clockwise front winding.
+func WithFaceCullingOption(enabled bool) Option {
+	return func(r *Renderer) error {
+		r.faceCulling = enabled
+		return nil
+	}
+}
+
+// WithBlendingOption .
+func WithBlendingOption(enabled bool) Option {
+	return func(r *Renderer) error {
+		r.blending = enabled
+		return nil
+	}
+}
+
+// WithSRGBOption .
+func WithSRGBOption(enabled bool) Option {
+	return func(r *Renderer) error {
+		r.srgb = enabled
+		return nil
+	}
+}
+
+// WithStencilTestOption .
+func WithStencilTestOption(enabled bool) Option {
+	return func(r *Renderer) error {
+		r.stencilTest = enabled
+		return nil
+	}
+}
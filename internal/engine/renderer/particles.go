@@ -0,0 +1,255 @@
+package renderer
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/devodev/opengl-experiment/internal/opengl"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+var (
+	defaultParticleLifetime  = float32(2)
+	defaultParticleVelocity  = mgl32.Vec3{0, 1, 0}
+	defaultParticleSpread    = float32(0.5)
+	defaultParticleGravity   = mgl32.Vec3{0, -0.98, 0}
+	defaultParticleColor     = mgl32.Vec4{1, 0.6, 0.2, 1}
+	defaultParticlePointSize = float32(8)
+	// position (vec3) and color (vec4)
+	particleVertexFloats = 7
+)
+
+// The particle shader expects positions (vec3) at location 0 and
+// colors (vec4) at location 1, drawn as round point sprites of
+// pointSize pixels.
+const (
+	particleVertexShader = `
+#version 460 core
+layout (location = 0) in vec3 position;
+layout (location = 1) in vec4 color;
+
+out vec4 fragColor;
+
+uniform mat4 vp;
+uniform float pointSize;
+
+void main() {
+    fragColor = color;
+    gl_Position = vp * vec4(position, 1.0);
+    gl_PointSize = pointSize;
+}
+    `
+
+	particleFragmentShader = `
+#version 460 core
+layout (location = 0) out vec4 outColor;
+
+in vec4 fragColor;
+
+void main() {
+    vec2 coord = gl_PointCoord * 2.0 - 1.0;
+    float distance = dot(coord, coord);
+    if (distance > 1.0) {
+        discard;
+    }
+    outColor = vec4(fragColor.rgb, fragColor.a * (1.0 - distance));
+}
+    `
+)
+
+// particle is a single simulated point.
+type particle struct {
+	position mgl32.Vec3
+	velocity mgl32.Vec3
+	age      float32
+}
+
+// ParticleSystem simulates point particles on the CPU, spawned at
+// the origin with a random velocity around a base one and pulled
+// by gravity. Particles fade out over their lifetime and, once
+// dead, their slot is reused by the next ones spawned.
+type ParticleSystem struct {
+	mesh          *DynamicMesh
+	shaderProgram *opengl.ShaderProgram
+
+	// particles[:alive] are alive
+	particles []particle
+	alive     int
+	vertices  []float32
+
+	origin    mgl32.Vec3
+	spawnRate float32
+	lifetime  float32
+	velocity  mgl32.Vec3
+	spread    float32
+	gravity   mgl32.Vec3
+	color     mgl32.Vec4
+	pointSize float32
+	// fraction of a particle left to spawn at the next update
+	spawnAccumulator float32
+}
+
+// NewParticleSystem allocates room for maxParticles alive at once,
+// particles spawned past that are dropped. Nothing is spawned
+// continuously until SetSpawnRate is called.
+func NewParticleSystem(maxParticles int) (*ParticleSystem, error) {
+	if maxParticles <= 0 {
+		return nil, fmt.Errorf("invalid maximum number of particles: %d", maxParticles)
+	}
+	layout := opengl.NewVBOLayout(
+		opengl.VBOLayoutElement{Count: 3, Normalized: false, DataType: opengl.GLDataTypeFloat},
+		opengl.VBOLayoutElement{Count: 4, Normalized: false, DataType: opengl.GLDataTypeFloat},
+	)
+	mesh, err := NewDynamicMeshArrays(layout, maxParticles)
+	if err != nil {
+		return nil, err
+	}
+	if err := mesh.SetPrimitive(gl.POINTS); err != nil {
+		mesh.Delete()
+		return nil, err
+	}
+	shaderProgram, err := opengl.NewShaderProgram(particleVertexShader+"\x00", particleFragmentShader+"\x00")
+	if err != nil {
+		mesh.Delete()
+		return nil, err
+	}
+	p := &ParticleSystem{
+		mesh:          mesh,
+		shaderProgram: shaderProgram,
+		particles:     make([]particle, maxParticles),
+		vertices:      make([]float32, 0, maxParticles*particleVertexFloats),
+		lifetime:      defaultParticleLifetime,
+		velocity:      defaultParticleVelocity,
+		spread:        defaultParticleSpread,
+		gravity:       defaultParticleGravity,
+		color:         defaultParticleColor,
+		pointSize:     defaultParticlePointSize,
+	}
+	return p, nil
+}
+
+// SetOrigin sets where particles are spawned, in world space.
+func (p *ParticleSystem) SetOrigin(origin mgl32.Vec3) {
+	p.origin = origin
+}
+
+// SetSpawnRate sets the number of particles spawned
+// per second by Update, 0 only spawns on Emit.
+func (p *ParticleSystem) SetSpawnRate(particlesPerSecond float32) {
+	p.spawnRate = particlesPerSecond
+}
+
+// SetLifetime sets the number of seconds particles live.
+func (p *ParticleSystem) SetLifetime(seconds float32) {
+	p.lifetime = seconds
+}
+
+// SetVelocity sets the initial velocity of particles, each
+// component being offset by a random value in [-spread, spread].
+func (p *ParticleSystem) SetVelocity(velocity mgl32.Vec3, spread float32) {
+	p.velocity = velocity
+	p.spread = spread
+}
+
+// SetGravity sets the acceleration applied to particles.
+func (p *ParticleSystem) SetGravity(gravity mgl32.Vec3) {
+	p.gravity = gravity
+}
+
+// SetColor sets the color of particles at birth,
+// their alpha decreasing to 0 over their lifetime.
+func (p *ParticleSystem) SetColor(color mgl32.Vec4) {
+	p.color = color
+}
+
+// SetPointSize sets the size of particles in pixels.
+func (p *ParticleSystem) SetPointSize(size float32) {
+	p.pointSize = size
+}
+
+// GetAliveCount .
+func (p *ParticleSystem) GetAliveCount() int {
+	return p.alive
+}
+
+// Emit spawns count particles at once.
+func (p *ParticleSystem) Emit(count int) {
+	for i := 0; i < count && p.alive < len(p.particles); i++ {
+		p.particles[p.alive] = particle{
+			position: p.origin,
+			velocity: p.velocity.Add(mgl32.Vec3{
+				p.randomSpread(),
+				p.randomSpread(),
+				p.randomSpread(),
+			}),
+		}
+		p.alive++
+	}
+}
+
+func (p *ParticleSystem) randomSpread() float32 {
+	return (rand.Float32()*2 - 1) * p.spread
+}
+
+// Update spawns particles according to the spawn rate, advances
+// the alive ones by deltaTime seconds and removes the dead ones.
+func (p *ParticleSystem) Update(deltaTime float32) {
+	p.spawnAccumulator += p.spawnRate * deltaTime
+	if spawn := int(p.spawnAccumulator); spawn > 0 {
+		p.spawnAccumulator -= float32(spawn)
+		p.Emit(spawn)
+	}
+
+	for i := 0; i < p.alive; {
+		particle := &p.particles[i]
+		particle.age += deltaTime
+		if particle.age >= p.lifetime {
+			// move the last alive particle into the dead one's slot
+			p.alive--
+			p.particles[i] = p.particles[p.alive]
+			continue
+		}
+		particle.velocity = particle.velocity.Add(p.gravity.Mul(deltaTime))
+		particle.position = particle.position.Add(particle.velocity.Mul(deltaTime))
+		i++
+	}
+}
+
+// Draw uploads the alive particles and draws them. Blending should
+// be enabled for particles to fade, ex: with BlendAdditive.
+func (p *ParticleSystem) Draw(cameraController *CameraController) {
+	if p.alive == 0 {
+		return
+	}
+	p.vertices = p.vertices[:0]
+	for _, particle := range p.particles[:p.alive] {
+		alpha := p.color[3] * (1 - particle.age/p.lifetime)
+		p.vertices = append(p.vertices,
+			particle.position[0], particle.position[1], particle.position[2],
+			p.color[0], p.color[1], p.color[2], alpha,
+		)
+	}
+	p.mesh.SetVertices(p.vertices)
+
+	// let the vertex shader set the point size
+	gl.Enable(gl.PROGRAM_POINT_SIZE)
+	defer gl.Disable(gl.PROGRAM_POINT_SIZE)
+
+	vp := cameraController.GetViewProjectionMatrix()
+	p.shaderProgram.Bind()
+	p.shaderProgram.SetUniformMatrix4fv("vp", 1, false, &vp[0])
+	p.shaderProgram.SetUniform1f("pointSize", p.pointSize)
+	p.mesh.Bind()
+
+	p.mesh.draw()
+
+	p.mesh.Unbind()
+	p.shaderProgram.Unbind()
+}
+
+// Delete .
+func (p *ParticleSystem) Delete() {
+	p.mesh.Delete()
+	p.shaderProgram.Delete()
+}
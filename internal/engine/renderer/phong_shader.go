@@ -0,0 +1,80 @@
+package renderer
+
+import (
+	"github.com/devodev/opengl-experiment/internal/opengl"
+)
+
+// The Phong shader expects positions (vec3) at location 0 and
+// normals (vec3) at location 1, compatible with NewPrimitiveLayout.
+//
+// Uniforms are model and vp (mat4), normalMatrix (mat3) being the
+// NormalMatrix of model as set by DrawMeshWithTransform, falling back
+// to the upper left 3x3 of model when unset, lightDirection (vec3) being the
+// direction the light travels in world space, lightColor and
+// objectColor (vec3), and viewPosition (vec3) the camera position.
+const (
+	phongVertexShader = `
+#version 460 core
+layout (location = 0) in vec3 position;
+layout (location = 1) in vec3 normal;
+
+out vec3 fragPosition;
+out vec3 fragNormal;
+
+uniform mat4 model;
+uniform mat4 vp;
+uniform mat3 normalMatrix;
+
+void main() {
+    vec4 worldPosition = model * vec4(position, 1.0);
+    fragPosition = worldPosition.xyz;
+    // a normal matrix is never singular, all zeros means it was not uploaded
+    mat3 normalTransform = normalMatrix;
+    if (normalTransform == mat3(0.0)) {
+        normalTransform = mat3(model);
+    }
+    fragNormal = normalTransform * normal;
+    gl_Position = vp * worldPosition;
+}
+    `
+
+	phongFragmentShader = `
+#version 460 core
+layout (location = 0) out vec4 fragColor;
+
+in vec3 fragPosition;
+in vec3 fragNormal;
+
+uniform vec3 lightDirection;
+uniform vec3 lightColor;
+uniform vec3 objectColor;
+uniform vec3 viewPosition;
+
+const float ambientStrength = 0.1;
+const float specularStrength = 0.5;
+const float shininess = 32.0;
+
+void main() {
+    vec3 normal = normalize(fragNormal);
+    vec3 toLight = normalize(-lightDirection);
+
+    vec3 ambient = ambientStrength * lightColor;
+
+    float diffuseFactor = max(dot(normal, toLight), 0.0);
+    vec3 diffuse = diffuseFactor * lightColor;
+
+    vec3 toView = normalize(viewPosition - fragPosition);
+    vec3 reflected = reflect(-toLight, normal);
+    float specularFactor = pow(max(dot(toView, reflected), 0.0), shininess);
+    vec3 specular = specularStrength * specularFactor * lightColor;
+
+    fragColor = vec4((ambient + diffuse + specular) * objectColor, 1.0);
+}
+    `
+)
+
+// NewPhongShaderProgram returns a shader program implementing
+// Phong lighting from a single directional light.
+func NewPhongShaderProgram() (*opengl.ShaderProgram, error) {
+	return opengl.NewShaderProgram(phongVertexShader+"\x00", phongFragmentShader+"\x00")
+}
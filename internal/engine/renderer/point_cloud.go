@@ -0,0 +1,99 @@
+package renderer
+
+import (
+	"fmt"
+
+	"github.com/devodev/opengl-experiment/internal/opengl"
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+var (
+	defaultPointCloudPointSize = float32(4)
+)
+
+// The point cloud shader expects positions (vec3) at location 0
+// and colors (vec4) at location 1, transformed by vp (mat4) and
+// drawn as squares of pointSize (float) pixels.
+const (
+	pointCloudVertexShader = `
+#version 460 core
+layout (location = 0) in vec3 position;
+layout (location = 1) in vec4 color;
+
+out vec4 fragColor;
+
+uniform mat4 vp;
+uniform float pointSize;
+
+void main() {
+    fragColor = color;
+    gl_Position = vp * vec4(position, 1.0);
+    gl_PointSize = pointSize;
+}
+    `
+
+	pointCloudFragmentShader = `
+#version 460 core
+layout (location = 0) out vec4 outColor;
+
+in vec4 fragColor;
+
+void main() {
+    outColor = fragColor;
+}
+    `
+)
+
+// NewPointCloud interleaves positions (3 floats per point) and colors
+// (4 floats per point, RGBA) into a mesh drawn as gl.POINTS, using
+// NewVertexColorLayout. Draw it with DrawPointCloud
+// and the program returned by NewPointCloudShaderProgram.
+func NewPointCloud(positions []float32, colors []float32) (*Mesh, error) {
+	if len(positions) == 0 || len(positions)%3 != 0 {
+		return nil, fmt.Errorf("invalid point cloud positions length: %d is not a non-zero multiple of 3", len(positions))
+	}
+	count := len(positions) / 3
+	if len(colors) != count*4 {
+		return nil, fmt.Errorf("point cloud colors length %d does not match %d points: %d expected", len(colors), count, count*4)
+	}
+	vertices := make([]float32, 0, count*7)
+	for i := 0; i < count; i++ {
+		vertices = append(vertices, positions[i*3:i*3+3]...)
+		vertices = append(vertices, colors[i*4:i*4+4]...)
+	}
+	mesh, err := NewMeshArrays(vertices, NewVertexColorLayout())
+	if err != nil {
+		return nil, err
+	}
+	if err := mesh.SetPrimitive(gl.POINTS); err != nil {
+		mesh.Delete()
+		return nil, err
+	}
+	return mesh, nil
+}
+
+// NewPointCloudShaderProgram returns the program drawing point clouds,
+// passing colors through. The point size defaults to 4 pixels and is
+// changed through the pointSize uniform.
+func NewPointCloudShaderProgram() (*opengl.ShaderProgram, error) {
+	shaderProgram, err := opengl.NewShaderProgram(pointCloudVertexShader+"\x00", pointCloudFragmentShader+"\x00")
+	if err != nil {
+		return nil, err
+	}
+	shaderProgram.Bind()
+	shaderProgram.SetUniform1f("pointSize", defaultPointCloudPointSize)
+	shaderProgram.Unbind()
+	return shaderProgram, nil
+}
+
+// DrawPointCloud draws a point cloud from the point of view of the
+// camera, letting the shader program set the size of the points.
+func (r *Renderer) DrawPointCloud(mesh *Mesh, shaderProgram *opengl.ShaderProgram, cameraController *CameraController) {
+	gl.Enable(gl.PROGRAM_POINT_SIZE)
+	defer gl.Disable(gl.PROGRAM_POINT_SIZE)
+
+	vp := cameraController.GetViewProjectionMatrix()
+	shaderProgram.Bind()
+	shaderProgram.SetUniformMatrix4fv("vp", 1, false, &vp[0])
+	r.DrawMesh(mesh, shaderProgram)
+}
@@ -0,0 +1,95 @@
+package renderer
+
+import (
+	"github.com/devodev/opengl-experiment/internal/opengl"
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// Post-processing shaders receive clip space positions (vec2) at
+// location 0 and texture coordinates (vec2) at location 1, the input
+// texture being bound to unit 0 and the screenTexture sampler.
+const (
+	postProcessVertexShader = `
+#version 460 core
+layout (location = 0) in vec2 position;
+layout (location = 1) in vec2 texCoord;
+
+out vec2 fragTexCoord;
+
+void main() {
+    fragTexCoord = texCoord;
+    gl_Position = vec4(position, 0.0, 1.0);
+}
+    `
+
+	passthroughFragmentShader = `
+#version 460 core
+layout (location = 0) out vec4 fragColor;
+
+in vec2 fragTexCoord;
+
+uniform sampler2D screenTexture;
+
+void main() {
+    fragColor = texture(screenTexture, fragTexCoord);
+}
+    `
+)
+
+// NewFullscreenQuad returns a mesh covering clip space, with
+// positions (vec2) at location 0 and texture coordinates
+// (vec2) at location 1.
+func NewFullscreenQuad() (*Mesh, error) {
+	vertices := []float32{
+		-1, -1, 0, 0,
+		1, -1, 1, 0,
+		1, 1, 1, 1,
+		-1, 1, 0, 1,
+	}
+	indices := []uint32{0, 1, 2, 0, 2, 3}
+	layout := opengl.NewVBOLayout(
+		opengl.VBOLayoutElement{Count: 2, Normalized: false, DataType: opengl.GLDataTypeFloat},
+		opengl.VBOLayoutElement{Count: 2, Normalized: false, DataType: opengl.GLDataTypeFloat},
+	)
+	return NewMesh(vertices, indices, layout)
+}
+
+// NewPostProcessShaderProgram compiles a post-processing fragment
+// shader, which must be a null terminated string, with the vertex
+// shader used by DrawPostProcess.
+func NewPostProcessShaderProgram(fragmentShaderSource string) (*opengl.ShaderProgram, error) {
+	return opengl.NewShaderProgram(postProcessVertexShader+"\x00", fragmentShaderSource)
+}
+
+// NewPassthroughShaderProgram returns a post-processing
+// program copying its input texture as is.
+func NewPassthroughShaderProgram() (*opengl.ShaderProgram, error) {
+	return NewPostProcessShaderProgram(passthroughFragmentShader + "\x00")
+}
+
+// DrawPostProcess draws inputTexture, usually the color texture of a
+// framebuffer, over the whole viewport through the shader program.
+// Depth testing, face culling, blending and wireframe are disabled
+// while drawing, so that the output replaces the target contents.
+func (r *Renderer) DrawPostProcess(shaderProgram *opengl.ShaderProgram, inputTexture uint32) {
+	gl.Disable(gl.DEPTH_TEST)
+	gl.Disable(gl.CULL_FACE)
+	gl.Disable(gl.BLEND)
+	gl.PolygonMode(gl.FRONT_AND_BACK, gl.FILL)
+	defer func() {
+		r.SetDepthTest(r.depthTest)
+		r.SetBlending(r.blending)
+		r.SetFaceCulling(r.faceCulling, r.cullBack, r.frontCCW)
+		r.SetWireframe(r.wireframe)
+	}()
+
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindTexture(gl.TEXTURE_2D, inputTexture)
+	defer gl.BindTexture(gl.TEXTURE_2D, 0)
+
+	shaderProgram.Bind()
+	if shaderProgram.HasUniform("screenTexture") {
+		shaderProgram.SetUniform1i("screenTexture", 0)
+	}
+	r.DrawMesh(r.fullscreenQuad, shaderProgram)
+}
@@ -0,0 +1,104 @@
+package renderer
+
+import (
+	"fmt"
+
+	"github.com/devodev/opengl-experiment/internal/opengl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// primitive faces are described by their normal and two axes
+// spanning the face, ordered so that u x v = normal which gives
+// a counter-clockwise winding when viewed from outside
+type primitiveFace struct {
+	normal mgl32.Vec3
+	u      mgl32.Vec3
+	v      mgl32.Vec3
+}
+
+var (
+	cubeFaces = []primitiveFace{
+		{normal: mgl32.Vec3{1, 0, 0}, u: mgl32.Vec3{0, 0, -1}, v: mgl32.Vec3{0, 1, 0}},
+		{normal: mgl32.Vec3{-1, 0, 0}, u: mgl32.Vec3{0, 0, 1}, v: mgl32.Vec3{0, 1, 0}},
+		{normal: mgl32.Vec3{0, 1, 0}, u: mgl32.Vec3{1, 0, 0}, v: mgl32.Vec3{0, 0, -1}},
+		{normal: mgl32.Vec3{0, -1, 0}, u: mgl32.Vec3{1, 0, 0}, v: mgl32.Vec3{0, 0, 1}},
+		{normal: mgl32.Vec3{0, 0, 1}, u: mgl32.Vec3{1, 0, 0}, v: mgl32.Vec3{0, 1, 0}},
+		{normal: mgl32.Vec3{0, 0, -1}, u: mgl32.Vec3{-1, 0, 0}, v: mgl32.Vec3{0, 1, 0}},
+	}
+	quadFace = primitiveFace{normal: mgl32.Vec3{0, 0, 1}, u: mgl32.Vec3{1, 0, 0}, v: mgl32.Vec3{0, 1, 0}}
+	gridFace = primitiveFace{normal: mgl32.Vec3{0, 1, 0}, u: mgl32.Vec3{1, 0, 0}, v: mgl32.Vec3{0, 0, -1}}
+)
+
+// NewPrimitiveLayout returns the vertex layout used by
+// generated primitives: position (vec3) at location 0,
+// normal (vec3) at location 1 and texture
+// coordinates (vec2) at location 2.
+func NewPrimitiveLayout() *opengl.VBOLayout {
+	return opengl.NewVBOLayout(
+		opengl.VBOLayoutElement{Count: 3, Normalized: false, DataType: opengl.GLDataTypeFloat},
+		opengl.VBOLayoutElement{Count: 3, Normalized: false, DataType: opengl.GLDataTypeFloat},
+		opengl.VBOLayoutElement{Count: 2, Normalized: false, DataType: opengl.GLDataTypeFloat},
+	)
+}
+
+// NewQuad returns a unit quad on the XY plane facing +Z.
+func NewQuad() (*Mesh, error) {
+	vertices, indices := quadFace.grid(1, mgl32.Vec3{})
+	return NewMesh(vertices, indices, NewPrimitiveLayout())
+}
+
+// NewCube returns a unit cube centered on the origin.
+// Each face has its own vertices so that normals are per face.
+func NewCube() (*Mesh, error) {
+	var vertices []float32
+	var indices []uint32
+	for _, face := range cubeFaces {
+		faceVertices, faceIndices := face.grid(1, face.normal.Mul(0.5))
+		offset := uint32(len(vertices) / 8)
+		for _, idx := range faceIndices {
+			indices = append(indices, offset+idx)
+		}
+		vertices = append(vertices, faceVertices...)
+	}
+	return NewMesh(vertices, indices, NewPrimitiveLayout())
+}
+
+// NewGrid returns a unit grid on the XZ plane facing +Y,
+// made of divisions x divisions cells.
+func NewGrid(divisions int) (*Mesh, error) {
+	if divisions < 1 {
+		return nil, fmt.Errorf("grid requires at least one division: %d", divisions)
+	}
+	vertices, indices := gridFace.grid(divisions, mgl32.Vec3{})
+	return NewMesh(vertices, indices, NewPrimitiveLayout())
+}
+
+// grid generates a unit face centered on center
+// made of divisions x divisions cells.
+func (f primitiveFace) grid(divisions int, center mgl32.Vec3) ([]float32, []uint32) {
+	size := divisions + 1
+	vertices := make([]float32, 0, size*size*8)
+	indices := make([]uint32, 0, divisions*divisions*6)
+	for j := 0; j < size; j++ {
+		for i := 0; i < size; i++ {
+			s := float32(i) / float32(divisions)
+			t := float32(j) / float32(divisions)
+			pos := center.Add(f.u.Mul(s - 0.5)).Add(f.v.Mul(t - 0.5))
+			vertices = append(vertices,
+				pos.X(), pos.Y(), pos.Z(),
+				f.normal.X(), f.normal.Y(), f.normal.Z(),
+				s, t,
+			)
+		}
+	}
+	for j := 0; j < divisions; j++ {
+		for i := 0; i < divisions; i++ {
+			a := uint32(j*size + i)
+			b := a + 1
+			c := b + uint32(size)
+			d := a + uint32(size)
+			indices = append(indices, a, b, c, c, d, a)
+		}
+	}
+	return vertices, indices
+}
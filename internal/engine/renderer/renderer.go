@@ -3,7 +3,6 @@ package renderer
 import (
 	"fmt"
 	"image/color"
-	"strings"
 	"unsafe"
 
 	"github.com/devodev/opengl-experiment/internal/opengl"
@@ -12,7 +11,20 @@ import (
 )
 
 var (
-	defaultBackgroundColor = color.RGBA{51, 75, 75, 1}
+	defaultBackgroundColor    = color.RGBA{51, 75, 75, 1}
+	defaultDebugNotifications = false
+	defaultDebugChecks        = false
+	defaultDepthTest          = true
+	defaultSRGB               = false
+	defaultDepthFunc          = uint32(gl.LESS)
+	defaultFaceCulling        = false
+	defaultCullBack           = true
+	defaultFrontCCW           = true
+	defaultBlending           = true
+	defaultBlendSrc           = uint32(gl.SRC_ALPHA)
+	defaultBlendDst           = uint32(gl.ONE_MINUS_SRC_ALPHA)
+	defaultStencilTest        = false
+	defaultStencilMask        = uint32(0xFF)
 )
 
 var (
@@ -36,34 +48,71 @@ var (
 	}
 )
 
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
-}
-
 // Renderer .
 type Renderer struct {
-	backgroundColor color.RGBA
+	backgroundColor    color.RGBA
+	debugNotifications bool
+	debugChecks        bool
+	wireframe          bool
+	depthTest          bool
+	depthFunc          uint32
+	faceCulling        bool
+	cullBack           bool
+	frontCCW           bool
+	blending           bool
+	blendSrc           uint32
+	blendDst           uint32
+	stencilTest        bool
+	stencilMask        uint32
+	srgb               bool
+	scissorTest        bool
+	// set during RenderViewports, Clear then only
+	// clears the viewport being rendered
+	renderingViewports bool
 
 	quadVertexArray   *opengl.VAO
 	quadVertexBuffer  *opengl.VBO
 	quadShaderProgram *opengl.ShaderProgram
 
+	fullscreenQuad *Mesh
+	// shapes queued by DrawTriangle and such, drawn
+	// by FlushDebugShapes with the camera of the last Begin
+	debugDraw        *DebugDraw
+	cameraController *CameraController
+	// created on the first call to DrawDepth
+	depthShaderProgram *opengl.ShaderProgram
+
 	quadData *QuadData
 }
 
 // New .
-func New() (*Renderer, error) {
+func New(options ...Option) (*Renderer, error) {
 	r := &Renderer{
-		backgroundColor: defaultBackgroundColor,
+		backgroundColor:    defaultBackgroundColor,
+		debugNotifications: defaultDebugNotifications,
+		debugChecks:        defaultDebugChecks,
+		depthTest:          defaultDepthTest,
+		depthFunc:          defaultDepthFunc,
+		faceCulling:        defaultFaceCulling,
+		cullBack:           defaultCullBack,
+		frontCCW:           defaultFrontCCW,
+		blending:           defaultBlending,
+		blendSrc:           defaultBlendSrc,
+		blendDst:           defaultBlendDst,
+		stencilTest:        defaultStencilTest,
+		stencilMask:        defaultStencilMask,
+		srgb:               defaultSRGB,
 		quadData: &QuadData{
 			Textures: make(map[int]*opengl.Texture),
 			Vertices: make([]QuadVertex, 0, maxVertices),
 			Indices:  make([]uint32, 0, maxIndices),
 		},
 	}
+	for _, opt := range options {
+		if err := opt(r); err != nil {
+			return nil, err
+		}
+	}
 	return r, nil
 }
 
@@ -76,7 +125,14 @@ func (r *Renderer) Init() error {
 	}
 
 	r.setDebugging()
-	r.setBlending()
+	r.SetBlending(r.blending)
+	r.SetBlendFunc(r.blendSrc, r.blendDst)
+	r.SetDepthTest(r.depthTest)
+	r.SetDepthFunc(r.depthFunc)
+	r.SetFaceCulling(r.faceCulling, r.cullBack, r.frontCCW)
+	r.SetStencilTest(r.stencilTest)
+	r.SetStencilMask(r.stencilMask)
+	r.SetSRGB(r.srgb)
 
 	// initialize quad data
 	quadVertexShaderSource := string(append([]byte(quadVertexShader), byte('\x00')))
@@ -114,10 +170,23 @@ func (r *Renderer) Init() error {
 	r.quadVertexBuffer = vbo
 	r.quadShaderProgram = shaderProgram
 
+	fullscreenQuad, err := NewFullscreenQuad()
+	if err != nil {
+		return err
+	}
+	r.fullscreenQuad = fullscreenQuad
+
+	debugDraw, err := NewDebugDraw()
+	if err != nil {
+		return err
+	}
+	r.debugDraw = debugDraw
+
 	return nil
 }
 
-// Clear .
+// Clear clears the whole framebuffer, or only the current
+// viewport when called from a RenderViewports callback.
 func (r *Renderer) Clear() {
 	// clear buffers
 	gl.ClearColor(
@@ -126,7 +195,280 @@ func (r *Renderer) Clear() {
 		float32(r.backgroundColor.B)/255,
 		float32(r.backgroundColor.A)/255,
 	)
-	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+	mask := uint32(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+	if r.stencilTest {
+		// the stencil mask also applies to clearing
+		gl.StencilMask(0xFF)
+		gl.ClearStencil(0)
+		mask |= gl.STENCIL_BUFFER_BIT
+	}
+	// the scissor box also applies to clearing, always clear the
+	// whole framebuffer except within RenderViewports, where it
+	// keeps the viewports rendered before intact
+	clearAll := r.scissorTest && !r.renderingViewports
+	if clearAll {
+		gl.Disable(gl.SCISSOR_TEST)
+	}
+	gl.Clear(mask)
+	if clearAll {
+		gl.Enable(gl.SCISSOR_TEST)
+	}
+	if r.stencilTest {
+		gl.StencilMask(r.stencilMask)
+	}
+}
+
+// SetScissor confines rendering to a rectangle, x and y being in
+// pixels from the top left corner of the current viewport to the
+// top left corner of the rectangle. Clear is not affected,
+// except within RenderViewports.
+func (r *Renderer) SetScissor(x, y, width, height int) {
+	var viewport [4]int32
+	gl.GetIntegerv(gl.VIEWPORT, &viewport[0])
+	// OpenGL window coordinates start at the bottom left corner
+	bottom := int(viewport[1]+viewport[3]) - y - height
+	gl.Scissor(viewport[0]+int32(x), int32(bottom), int32(width), int32(height))
+	gl.Enable(gl.SCISSOR_TEST)
+	r.scissorTest = true
+}
+
+// DisableScissor .
+func (r *Renderer) DisableScissor() {
+	gl.Disable(gl.SCISSOR_TEST)
+	r.scissorTest = false
+}
+
+// IsScissor .
+func (r *Renderer) IsScissor() bool {
+	return r.scissorTest
+}
+
+// SetViewport .
+func (r *Renderer) SetViewport(width, height int) {
+	gl.Viewport(0, 0, int32(width), int32(height))
+}
+
+// SetDepthTest requires the window to be created with a depth
+// buffer, which GLFW does by default. It is enabled by default.
+func (r *Renderer) SetDepthTest(enabled bool) {
+	r.depthTest = enabled
+	if r.depthTest {
+		gl.Enable(gl.DEPTH_TEST)
+	} else {
+		gl.Disable(gl.DEPTH_TEST)
+	}
+}
+
+// IsDepthTest .
+func (r *Renderer) IsDepthTest() bool {
+	return r.depthTest
+}
+
+// SetDepthFunc sets the depth comparison function,
+// gl.LESS by default.
+func (r *Renderer) SetDepthFunc(depthFunc uint32) {
+	r.depthFunc = depthFunc
+	gl.DepthFunc(r.depthFunc)
+}
+
+// SetStencilTest enables the stencil test, the stencil buffer is then
+// cleared along with the others. The window must have been created
+// with stencil bits, see window.WithStencilBitsOption.
+func (r *Renderer) SetStencilTest(enabled bool) {
+	r.stencilTest = enabled
+	if r.stencilTest {
+		gl.Enable(gl.STENCIL_TEST)
+	} else {
+		gl.Disable(gl.STENCIL_TEST)
+	}
+}
+
+// IsStencilTest .
+func (r *Renderer) IsStencilTest() bool {
+	return r.stencilTest
+}
+
+// SetStencilMask sets the bits of the stencil buffer that can
+// be written, 0x00 disables writing and 0xFF allows all of them.
+func (r *Renderer) SetStencilMask(mask uint32) {
+	r.stencilMask = mask
+	gl.StencilMask(r.stencilMask)
+}
+
+// SetStencilFunc sets the comparison, such as gl.ALWAYS or
+// gl.NOTEQUAL, between ref and the stored stencil value,
+// both masked with mask, for a fragment to pass the test.
+func (r *Renderer) SetStencilFunc(stencilFunc uint32, ref int32, mask uint32) {
+	gl.StencilFunc(stencilFunc, ref, mask)
+}
+
+// SetStencilOp sets the actions, such as gl.KEEP or gl.REPLACE,
+// taken when the stencil test fails, when it passes but the
+// depth test fails and when both pass.
+func (r *Renderer) SetStencilOp(stencilFail, depthFail, depthPass uint32) {
+	gl.StencilOp(stencilFail, depthFail, depthPass)
+}
+
+// SetFaceCulling culls back faces when cullBack is true, front faces
+// otherwise. Front faces have a counter-clockwise winding when
+// frontCCW is true, clockwise otherwise.
+func (r *Renderer) SetFaceCulling(enabled, cullBack, frontCCW bool) {
+	r.faceCulling = enabled
+	r.cullBack = cullBack
+	r.frontCCW = frontCCW
+	if r.faceCulling {
+		gl.Enable(gl.CULL_FACE)
+	} else {
+		gl.Disable(gl.CULL_FACE)
+	}
+	if r.cullBack {
+		gl.CullFace(gl.BACK)
+	} else {
+		gl.CullFace(gl.FRONT)
+	}
+	if r.frontCCW {
+		gl.FrontFace(gl.CCW)
+	} else {
+		gl.FrontFace(gl.CW)
+	}
+}
+
+// IsFaceCulling .
+func (r *Renderer) IsFaceCulling() bool {
+	return r.faceCulling
+}
+
+// SetBlending .
+func (r *Renderer) SetBlending(enabled bool) {
+	r.blending = enabled
+	if r.blending {
+		gl.Enable(gl.BLEND)
+	} else {
+		gl.Disable(gl.BLEND)
+	}
+}
+
+// IsBlending .
+func (r *Renderer) IsBlending() bool {
+	return r.blending
+}
+
+// SetBlendFunc sets the source and destination blending factors,
+// gl.SRC_ALPHA and gl.ONE_MINUS_SRC_ALPHA by default.
+func (r *Renderer) SetBlendFunc(src, dst uint32) {
+	r.blendSrc = src
+	r.blendDst = dst
+	gl.BlendFunc(r.blendSrc, r.blendDst)
+}
+
+// SetMultisample requires the window to be created
+// with samples, see window.WithSamplesOption.
+func (r *Renderer) SetMultisample(enabled bool) {
+	if enabled {
+		gl.Enable(gl.MULTISAMPLE)
+	} else {
+		gl.Disable(gl.MULTISAMPLE)
+	}
+}
+
+// SetBackgroundColor sets the color used by Clear.
+func (r *Renderer) SetBackgroundColor(c color.RGBA) {
+	r.backgroundColor = c
+}
+
+// GetBackgroundColor .
+func (r *Renderer) GetBackgroundColor() color.RGBA {
+	return r.backgroundColor
+}
+
+// SetWireframe switches between filled and wireframe polygons.
+// It takes effect on the next draw call.
+func (r *Renderer) SetWireframe(enabled bool) {
+	r.wireframe = enabled
+	if r.wireframe {
+		gl.PolygonMode(gl.FRONT_AND_BACK, gl.LINE)
+	} else {
+		gl.PolygonMode(gl.FRONT_AND_BACK, gl.FILL)
+	}
+}
+
+// SetSRGB enables GL_FRAMEBUFFER_SRGB: colors output by shaders
+// are considered linear and gamma encoded by the GPU when written
+// to an sRGB capable framebuffer, see window.WithSRGBOption.
+// Textures holding colors should then be created with
+// TextureOptions.SRGB so that sampling them returns linear values.
+func (r *Renderer) SetSRGB(enabled bool) {
+	r.srgb = enabled
+	if r.srgb {
+		gl.Enable(gl.FRAMEBUFFER_SRGB)
+	} else {
+		gl.Disable(gl.FRAMEBUFFER_SRGB)
+	}
+}
+
+// IsSRGB .
+func (r *Renderer) IsSRGB() bool {
+	return r.srgb
+}
+
+// IsWireframe .
+func (r *Renderer) IsWireframe() bool {
+	return r.wireframe
+}
+
+// SetPolygonOffset offsets the depth of polygons by
+// factor * slope + units * the smallest depth step, to avoid z-fighting
+// between coplanar geometry. Positive values push polygons away.
+//
+// The offset applies to polygons rasterized as filled triangles
+// (GL_POLYGON_OFFSET_FILL) and as wireframe (GL_POLYGON_OFFSET_LINE),
+// ex: to overlay a wireframe on a solid mesh, draw the solid pass with
+// a positive offset, such as 1, 1, then the wireframe without it.
+// Decals are drawn with a negative offset instead, pulling them
+// in front of the surface they lie on.
+func (r *Renderer) SetPolygonOffset(factor, units float32) {
+	gl.PolygonOffset(factor, units)
+	gl.Enable(gl.POLYGON_OFFSET_FILL)
+	gl.Enable(gl.POLYGON_OFFSET_LINE)
+}
+
+// DisablePolygonOffset .
+func (r *Renderer) DisablePolygonOffset() {
+	gl.Disable(gl.POLYGON_OFFSET_FILL)
+	gl.Disable(gl.POLYGON_OFFSET_LINE)
+}
+
+// SetPointSize sets the size in pixels of rasterized points,
+// clamped to the supported range. Point clouds and particles
+// override it with the size written by their shader.
+func (r *Renderer) SetPointSize(size float32) {
+	gl.PointSize(clampToRange(gl.POINT_SIZE_RANGE, "point size", size))
+}
+
+// SetLineWidth sets the width in pixels of rasterized lines,
+// clamped to the supported range. Wide lines are not supported
+// by forward compatible contexts, the default, where widths
+// above 1 are an error and the width is clamped to 1.
+func (r *Renderer) SetLineWidth(width float32) {
+	var flags int32
+	gl.GetIntegerv(gl.CONTEXT_FLAGS, &flags)
+	if flags&gl.CONTEXT_FLAG_FORWARD_COMPATIBLE_BIT != 0 && width > 1 {
+		fmt.Printf("[OpenGL WARNING] line width %.1f not supported by forward compatible contexts, clamping to 1\n", width)
+		width = 1
+	}
+	gl.LineWidth(clampToRange(gl.ALIASED_LINE_WIDTH_RANGE, "line width", width))
+}
+
+// clampToRange clamps v to the [min, max] range
+// returned by querying pname, warning when out of it.
+func clampToRange(pname uint32, name string, v float32) float32 {
+	var valueRange [2]float32
+	gl.GetFloatv(pname, &valueRange[0])
+	if v < valueRange[0] || v > valueRange[1] {
+		fmt.Printf("[OpenGL WARNING] %s %.1f not supported, clamping to [%.1f, %.1f]\n", name, v, valueRange[0], valueRange[1])
+		return mgl32.Clamp(v, valueRange[0], valueRange[1])
+	}
+	return v
 }
 
 // Begin .
@@ -142,9 +484,12 @@ func (r *Renderer) Begin(cameraController *CameraController) {
 
 	vp := cameraController.GetViewProjectionMatrix()
 	r.quadShaderProgram.SetUniformMatrix4fv("vp", 1, false, &vp[0])
+	r.cameraController = cameraController
 }
 
-// End .
+// End draws the quads queued since Begin in submission order,
+// the depth test is disabled while drawing so that overlapping
+// quads layer instead of being rejected.
 func (r *Renderer) End() {
 	// fmt.Printf("> End\n")
 	// fmt.Printf("\tVertices: %v\n", r.quadData.Vertices)
@@ -152,6 +497,7 @@ func (r *Renderer) End() {
 	// fmt.Printf("\tTextures: %v\n", r.quadData.Textures)
 	r.quadVertexBuffer.SetData(r.quadData)
 	r.quadVertexArray.GetIBO().SetData(r.quadData)
+	recordUpload(r.quadData.GetVBOSize() + 4*int(r.quadData.GetIBOCount()))
 
 	for _, t := range r.quadData.Textures {
 		t.Bind()
@@ -159,7 +505,15 @@ func (r *Renderer) End() {
 	r.quadShaderProgram.Bind()
 	r.quadVertexArray.Bind()
 
+	depthTest := gl.IsEnabled(gl.DEPTH_TEST)
+	if depthTest {
+		gl.Disable(gl.DEPTH_TEST)
+	}
+
 	defer func() {
+		if depthTest {
+			gl.Enable(gl.DEPTH_TEST)
+		}
 		for _, t := range r.quadData.Textures {
 			t.Unbind()
 		}
@@ -167,11 +521,53 @@ func (r *Renderer) End() {
 		r.quadShaderProgram.Unbind()
 	}()
 
-	count := r.quadVertexArray.GetIBO().GetCount()
-	gl.DrawElements(gl.TRIANGLES, int32(count), gl.UNSIGNED_INT, nil)
+	checkDrawState("quad batch draw")
+	ibo := r.quadVertexArray.GetIBO()
+	recordDraw(gl.TRIANGLES, ibo.GetCount(), 1)
+	gl.DrawElements(gl.TRIANGLES, ibo.GetCount(), ibo.GetIndexType(), nil)
 	// fmt.Printf("< End\n")
 }
 
+// DrawMesh draws the mesh using the shader program.
+// Uniforms must be set by the caller beforehand.
+func (r *Renderer) DrawMesh(mesh *Mesh, shaderProgram *opengl.ShaderProgram) {
+	shaderProgram.Bind()
+	mesh.Bind()
+	defer func() {
+		mesh.Unbind()
+		shaderProgram.Unbind()
+	}()
+
+	mesh.draw()
+}
+
+// DrawMeshWithTransform draws the mesh after uploading
+// transform to the "model" uniform of the shader program,
+// and its NormalMatrix to "normalMatrix" if the program has it.
+func (r *Renderer) DrawMeshWithTransform(mesh *Mesh, shaderProgram *opengl.ShaderProgram, transform mgl32.Mat4) {
+	shaderProgram.Bind()
+	shaderProgram.SetUniformMatrix4fv("model", 1, false, &transform[0])
+	if shaderProgram.HasUniform("normalMatrix") {
+		normalMatrix := NormalMatrix(transform)
+		shaderProgram.SetUniformMatrix3fv("normalMatrix", 1, false, &normalMatrix[0])
+	}
+	r.DrawMesh(mesh, shaderProgram)
+}
+
+// DrawMeshInstanced draws count instances of the mesh in a single
+// draw call. Per-instance data is provided through a VBO added
+// to the mesh with a layout using a non-zero Divisor.
+func (r *Renderer) DrawMeshInstanced(mesh *Mesh, shaderProgram *opengl.ShaderProgram, count int32) {
+	shaderProgram.Bind()
+	mesh.Bind()
+	defer func() {
+		mesh.Unbind()
+		shaderProgram.Unbind()
+	}()
+
+	mesh.drawInstanced(count)
+}
+
 // DrawTexturedQuad .
 func (r *Renderer) DrawTexturedQuad(transform mgl32.Mat4, texture *opengl.Texture) {
 	if err := r.quadData.AddTexturedQuad(transform, texture); err != nil {
@@ -180,35 +576,8 @@ func (r *Renderer) DrawTexturedQuad(transform mgl32.Mat4, texture *opengl.Textur
 }
 
 func (r *Renderer) setDebugging() {
-	gl.Enable(gl.DEBUG_OUTPUT)
-	gl.DebugMessageCallback(func(
-		source uint32,
-		gltype uint32,
-		id uint32,
-		severity uint32,
-		length int32,
-		message string,
-		userParam unsafe.Pointer) {
-
-		fmt.Println("[OpenGL DEBUG]")
-		fmt.Printf("[OpenGL DEBUG]\tsource (0x%x): %v\n", source, strings.Join(opengl.GlEnums[source], ", "))
-		fmt.Printf("[OpenGL DEBUG]\tgltype (0x%x): %v\n", gltype, strings.Join(opengl.GlEnums[gltype], ", "))
-		fmt.Printf("[OpenGL DEBUG]\tseverity (0x%x): %v\n", severity, strings.Join(opengl.GlEnums[severity], ", "))
-		fmt.Printf("[OpenGL DEBUG]\tid: %v\n", id)
-		fmt.Println("[OpenGL DEBUG]\tmessage:")
-		lineLen := 90
-		msgIdx := 0
-		for msgIdx < len(message) {
-			fmt.Printf("[OpenGL DEBUG]\t\t%v\n", strings.TrimLeft(message[msgIdx:min(msgIdx+lineLen, len(message))], "  "))
-			msgIdx += lineLen
-		}
-		fmt.Println("[OpenGL DEBUG]")
-	}, nil)
-}
-
-func (r *Renderer) setBlending() {
-	gl.Enable(gl.BLEND)
-	gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+	opengl.EnableDebugOutput(r.debugNotifications)
+	opengl.DebugChecks = r.debugChecks
 }
 
 // QuadVertex .
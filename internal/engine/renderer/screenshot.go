@@ -0,0 +1,56 @@
+package renderer
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// SaveScreenshot writes the content of the back buffer of the
+// default framebuffer to path as a PNG image. It must be called
+// after drawing and before the buffers are swapped, typically
+// at the end of a layer OnRender.
+//
+// width and height are in pixels, see window.GetFramebufferSize.
+func (r *Renderer) SaveScreenshot(path string, width, height int) error {
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("invalid screenshot dimensions: %dx%d", width, height)
+	}
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	// rows are tightly packed, so that the stride
+	// of the image matches the one of the pixel data
+	gl.BindFramebuffer(gl.READ_FRAMEBUFFER, 0)
+	gl.ReadBuffer(gl.BACK)
+	gl.PixelStorei(gl.PACK_ALIGNMENT, 1)
+	gl.ReadPixels(0, 0, int32(width), int32(height), gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(img.Pix))
+
+	// OpenGL origin is bottom left while images are top left
+	stride := img.Stride
+	row := make([]uint8, stride)
+	for y := 0; y < height/2; y++ {
+		top := img.Pix[y*stride : (y+1)*stride]
+		bottom := img.Pix[(height-1-y)*stride : (height-y)*stride]
+		copy(row, top)
+		copy(top, bottom)
+		copy(bottom, row)
+	}
+	// the default framebuffer alpha is meaningless once displayed
+	for i := 3; i < len(img.Pix); i += 4 {
+		img.Pix[i] = 255
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating screenshot file %q: %s", path, err)
+	}
+	defer f.Close()
+
+	if err := png.Encode(f, img); err != nil {
+		return fmt.Errorf("error encoding screenshot: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package renderer
+
+import (
+	"github.com/devodev/opengl-experiment/internal/opengl"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+var (
+	defaultSpriteBatchMaxSprites = 2000
+	defaultSpriteColor           = mgl32.Vec4{1, 1, 1, 1}
+	// position (vec2), texture coordinates (vec2) and color (vec4)
+	spriteVertexFloats = 8
+)
+
+// The sprite shader expects screen positions (vec2) at location 0,
+// texture coordinates (vec2) at location 1 and colors (vec4) at
+// location 2.
+const (
+	spriteVertexShader = `
+#version 460 core
+layout (location = 0) in vec2 position;
+layout (location = 1) in vec2 texCoord;
+layout (location = 2) in vec4 color;
+
+out vec2 fragTexCoord;
+out vec4 fragColor;
+
+uniform mat4 projection;
+
+void main() {
+    fragTexCoord = texCoord;
+    fragColor = color;
+    gl_Position = projection * vec4(position, 0.0, 1.0);
+}
+    `
+
+	spriteFragmentShader = `
+#version 460 core
+layout (location = 0) out vec4 outColor;
+
+in vec2 fragTexCoord;
+in vec4 fragColor;
+
+uniform sampler2D sprite;
+
+void main() {
+    outColor = texture(sprite, fragTexCoord) * fragColor;
+}
+    `
+)
+
+// SpriteBatch draws textured quads in screen space, batching
+// consecutive sprites using the same texture in a single draw call.
+// A batch is flushed when the texture changes, when it is full and
+// on End.
+type SpriteBatch struct {
+	mesh          *DynamicMesh
+	shaderProgram *opengl.ShaderProgram
+	maxSprites    int
+	color         mgl32.Vec4
+
+	drawing  bool
+	texture  *opengl.Texture
+	vertices []float32
+	indices  []uint32
+}
+
+// NewSpriteBatch .
+func NewSpriteBatch() (*SpriteBatch, error) {
+	layout := opengl.NewVBOLayout(
+		opengl.VBOLayoutElement{Count: 2, Normalized: false, DataType: opengl.GLDataTypeFloat},
+		opengl.VBOLayoutElement{Count: 2, Normalized: false, DataType: opengl.GLDataTypeFloat},
+		opengl.VBOLayoutElement{Count: 4, Normalized: false, DataType: opengl.GLDataTypeFloat},
+	)
+	maxSprites := defaultSpriteBatchMaxSprites
+	mesh, err := NewDynamicMesh(layout, maxSprites*4, maxSprites*6)
+	if err != nil {
+		return nil, err
+	}
+	shaderProgram, err := opengl.NewShaderProgram(spriteVertexShader+"\x00", spriteFragmentShader+"\x00")
+	if err != nil {
+		mesh.Delete()
+		return nil, err
+	}
+	b := &SpriteBatch{
+		mesh:          mesh,
+		shaderProgram: shaderProgram,
+		maxSprites:    maxSprites,
+		color:         defaultSpriteColor,
+		vertices:      make([]float32, 0, maxSprites*4*spriteVertexFloats),
+		indices:       make([]uint32, 0, maxSprites*6),
+	}
+	return b, nil
+}
+
+// SetColor sets the color multiplied with the
+// texture of the sprites drawn next.
+func (b *SpriteBatch) SetColor(color mgl32.Vec4) {
+	b.color = color
+}
+
+// Begin starts a batch, sprites are only drawn between Begin and End.
+func (b *SpriteBatch) Begin() {
+	b.drawing = true
+	b.texture = nil
+	b.vertices = b.vertices[:0]
+	b.indices = b.indices[:0]
+}
+
+// Draw queues the whole texture at x and y, in pixels from the top left
+// corner of the viewport to the top left corner of the sprite,
+// stretched to width and height.
+func (b *SpriteBatch) Draw(texture *opengl.Texture, x, y, width, height float32) {
+	b.draw(texture, x, y, width, height, 0, 0, 1, 1)
+}
+
+// DrawRegion queues a region of a texture like Draw.
+func (b *SpriteBatch) DrawRegion(region *TextureRegion, x, y, width, height float32) {
+	b.draw(region.texture, x, y, width, height, region.u0, region.v0, region.u1, region.v1)
+}
+
+func (b *SpriteBatch) draw(texture *opengl.Texture, x, y, width, height, u0, v0, u1, v1 float32) {
+	if !b.drawing {
+		return
+	}
+	if b.texture != texture || len(b.indices)/6 >= b.maxSprites {
+		b.flush()
+		b.texture = texture
+	}
+	c := b.color
+	offset := uint32(len(b.vertices) / spriteVertexFloats)
+	b.vertices = append(b.vertices,
+		x, y, u0, v1, c[0], c[1], c[2], c[3],
+		x, y+height, u0, v0, c[0], c[1], c[2], c[3],
+		x+width, y+height, u1, v0, c[0], c[1], c[2], c[3],
+		x+width, y, u1, v1, c[0], c[1], c[2], c[3],
+	)
+	b.indices = append(b.indices,
+		offset, offset+1, offset+2,
+		offset, offset+2, offset+3,
+	)
+}
+
+// End draws the sprites queued since the last flush.
+// The depth test is disabled while drawing.
+func (b *SpriteBatch) End() {
+	b.flush()
+	b.drawing = false
+}
+
+func (b *SpriteBatch) flush() {
+	if len(b.indices) == 0 || b.texture == nil {
+		return
+	}
+	b.mesh.SetVertices(b.vertices)
+	b.mesh.SetIndices(b.indices)
+
+	projection := screenProjection()
+
+	depthTest := gl.IsEnabled(gl.DEPTH_TEST)
+	if depthTest {
+		gl.Disable(gl.DEPTH_TEST)
+	}
+
+	b.shaderProgram.Bind()
+	b.shaderProgram.SetUniformMatrix4fv("projection", 1, false, &projection[0])
+	b.shaderProgram.SetUniform1i("sprite", int32(b.texture.GetIndex()))
+	b.texture.Bind()
+	b.mesh.Bind()
+
+	b.mesh.draw()
+
+	b.mesh.Unbind()
+	b.texture.Unbind()
+	b.shaderProgram.Unbind()
+	if depthTest {
+		gl.Enable(gl.DEPTH_TEST)
+	}
+
+	b.vertices = b.vertices[:0]
+	b.indices = b.indices[:0]
+}
+
+// Delete .
+func (b *SpriteBatch) Delete() {
+	b.mesh.Delete()
+	b.shaderProgram.Delete()
+}
+
+// screenProjection maps pixels of the current viewport,
+// from the top left corner, to clip space.
+func screenProjection() mgl32.Mat4 {
+	var viewport [4]int32
+	gl.GetIntegerv(gl.VIEWPORT, &viewport[0])
+	return mgl32.Ortho(0, float32(viewport[2]), float32(viewport[3]), 0, -1, 1)
+}
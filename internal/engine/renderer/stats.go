@@ -0,0 +1,47 @@
+package renderer
+
+import "github.com/go-gl/gl/v4.6-core/gl"
+
+// RenderStats counts the work submitted to the GPU
+// since the last call to ResetStats.
+type RenderStats struct {
+	DrawCalls int
+	Triangles int
+	// BufferBytes is the amount of vertex and index
+	// data uploaded by meshes and batches
+	BufferBytes int
+}
+
+var stats RenderStats
+
+// ResetStats clears the counters, called by the
+// application at the start of every frame.
+func ResetStats() {
+	stats = RenderStats{}
+}
+
+// Stats returns the counters accumulated since the last ResetStats,
+// only the work submitted so far when called in the middle of a frame.
+func Stats() RenderStats {
+	return stats
+}
+
+// recordDraw counts a draw call of count vertices or indices
+// assembled as primitive, drawn instances times.
+func recordDraw(primitive uint32, count int32, instances int32) {
+	stats.DrawCalls++
+	triangles := 0
+	switch primitive {
+	case gl.TRIANGLES:
+		triangles = int(count) / 3
+	case gl.TRIANGLE_STRIP, gl.TRIANGLE_FAN:
+		if count > 2 {
+			triangles = int(count) - 2
+		}
+	}
+	stats.Triangles += triangles * int(instances)
+}
+
+func recordUpload(bytes int) {
+	stats.BufferBytes += bytes
+}
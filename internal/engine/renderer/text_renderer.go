@@ -0,0 +1,245 @@
+package renderer
+
+import (
+	"github.com/devodev/opengl-experiment/internal/opengl"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+var (
+	defaultTextColor     = mgl32.Vec4{1, 1, 1, 1}
+	defaultTextMaxGlyphs = 1024
+)
+
+// The text shader expects screen positions (vec2) at location 0
+// and texture coordinates (vec2) at location 1.
+const (
+	textVertexShader = `
+#version 460 core
+layout (location = 0) in vec2 position;
+layout (location = 1) in vec2 texCoord;
+
+out vec2 fragTexCoord;
+
+uniform mat4 projection;
+
+void main() {
+    fragTexCoord = texCoord;
+    gl_Position = projection * vec4(position, 0.0, 1.0);
+}
+    `
+
+	textFragmentShader = `
+#version 460 core
+layout (location = 0) out vec4 fragColor;
+
+in vec2 fragTexCoord;
+
+uniform sampler2D glyphAtlas;
+uniform vec4 textColor;
+
+void main() {
+    float alpha = texture(glyphAtlas, fragTexCoord).a;
+    if (alpha == 0.0) {
+        discard;
+    }
+    fragColor = vec4(textColor.rgb, textColor.a * alpha);
+}
+    `
+)
+
+// TextRenderer draws strings using a BitmapFont, one quad per glyph.
+type TextRenderer struct {
+	font          *BitmapFont
+	mesh          *DynamicMesh
+	shaderProgram *opengl.ShaderProgram
+	color         mgl32.Vec4
+	// referenceDistance is the camera distance at which world
+	// text is drawn at its nominal scale, 0 disables scaling
+	referenceDistance float32
+
+	vertices []float32
+	indices  []uint32
+}
+
+// NewTextRenderer uses the built-in 8x8 font.
+func NewTextRenderer() (*TextRenderer, error) {
+	font, err := NewDefaultBitmapFont()
+	if err != nil {
+		return nil, err
+	}
+	return NewTextRendererWithFont(font)
+}
+
+// NewTextRendererWithFont .
+func NewTextRendererWithFont(font *BitmapFont) (*TextRenderer, error) {
+	layout := opengl.NewVBOLayout(
+		opengl.VBOLayoutElement{Count: 2, Normalized: false, DataType: opengl.GLDataTypeFloat},
+		opengl.VBOLayoutElement{Count: 2, Normalized: false, DataType: opengl.GLDataTypeFloat},
+	)
+	mesh, err := NewDynamicMesh(layout, defaultTextMaxGlyphs*4, defaultTextMaxGlyphs*6)
+	if err != nil {
+		return nil, err
+	}
+	shaderProgram, err := opengl.NewShaderProgram(textVertexShader+"\x00", textFragmentShader+"\x00")
+	if err != nil {
+		mesh.Delete()
+		return nil, err
+	}
+	t := &TextRenderer{
+		font:          font,
+		mesh:          mesh,
+		shaderProgram: shaderProgram,
+		color:         defaultTextColor,
+	}
+	return t, nil
+}
+
+// SetColor .
+func (t *TextRenderer) SetColor(color mgl32.Vec4) {
+	t.color = color
+}
+
+// SetReferenceDistance makes text drawn with DrawWorldText shrink
+// as it moves away from the camera: labels are drawn at their nominal
+// scale at the given distance, and inversely proportional to their
+// distance otherwise. 0, the default, keeps a constant size on screen.
+func (t *TextRenderer) SetReferenceDistance(distance float32) {
+	if distance < 0 {
+		distance = 0
+	}
+	t.referenceDistance = distance
+}
+
+// GetFont .
+func (t *TextRenderer) GetFont() *BitmapFont {
+	return t.font
+}
+
+// DrawText draws text in screen space: x and y are in pixels from
+// the top left corner of the viewport to the top left corner of
+// the first glyph, and scale multiplies the glyph size.
+// Newlines start a new line and characters missing from the font
+// are drawn as '?'. The depth test is disabled while drawing.
+func (t *TextRenderer) DrawText(text string, x, y float32, scale float32) {
+	t.vertices = t.vertices[:0]
+	t.indices = t.indices[:0]
+
+	glyphWidth := float32(t.font.glyphWidth) * scale
+	glyphHeight := float32(t.font.glyphHeight) * scale
+	penX, penY := x, y
+	for _, r := range text {
+		if r == '\n' {
+			penX = x
+			penY += glyphHeight
+			continue
+		}
+		if !t.font.HasGlyph(r) {
+			r = defaultFontFallback
+		}
+		if t.font.HasGlyph(r) {
+			t.addGlyph(r, penX, penY, glyphWidth, glyphHeight)
+		}
+		penX += glyphWidth
+	}
+	if len(t.indices) == 0 {
+		return
+	}
+	t.mesh.SetVertices(t.vertices)
+	t.mesh.SetIndices(t.indices)
+
+	projection := screenProjection()
+
+	depthTest := gl.IsEnabled(gl.DEPTH_TEST)
+	if depthTest {
+		gl.Disable(gl.DEPTH_TEST)
+	}
+
+	texture := t.font.GetTexture()
+	t.shaderProgram.Bind()
+	t.shaderProgram.SetUniformMatrix4fv("projection", 1, false, &projection[0])
+	t.shaderProgram.SetUniform4f("textColor", t.color[0], t.color[1], t.color[2], t.color[3])
+	t.shaderProgram.SetUniform1i("glyphAtlas", int32(texture.GetIndex()))
+	texture.Bind()
+	t.mesh.Bind()
+
+	t.mesh.draw()
+
+	t.mesh.Unbind()
+	texture.Unbind()
+	t.shaderProgram.Unbind()
+	if depthTest {
+		gl.Enable(gl.DEPTH_TEST)
+	}
+}
+
+// DrawWorldText draws text as a label facing the screen, centered on
+// the projection of worldPos. Labels behind the camera or projecting
+// outside of the viewport are not drawn. See SetReferenceDistance
+// to scale labels with their distance to the camera.
+func (t *TextRenderer) DrawWorldText(text string, worldPos mgl32.Vec3, cc *CameraController, scale float32) {
+	clip := cc.GetViewProjectionMatrix().Mul4x1(worldPos.Vec4(1))
+	if clip.W() <= 0 {
+		return
+	}
+	ndc := clip.Vec3().Mul(1 / clip.W())
+	if ndc.X() < -1 || ndc.X() > 1 || ndc.Y() < -1 || ndc.Y() > 1 || ndc.Z() < -1 || ndc.Z() > 1 {
+		return
+	}
+	if t.referenceDistance > 0 {
+		distance := worldPos.Sub(cc.GetPosition()).Len()
+		if distance > 0 {
+			scale *= t.referenceDistance / distance
+		}
+	}
+
+	var viewport [4]int32
+	gl.GetIntegerv(gl.VIEWPORT, &viewport[0])
+	x := (ndc.X() + 1) / 2 * float32(viewport[2])
+	y := (1 - ndc.Y()) / 2 * float32(viewport[3])
+
+	columns, lines := textSize(text)
+	x -= float32(columns*t.font.glyphWidth) * scale / 2
+	y -= float32(lines*t.font.glyphHeight) * scale / 2
+	t.DrawText(text, x, y, scale)
+}
+
+// textSize returns the length in glyphs of the
+// longest line of text, and the number of lines.
+func textSize(text string) (int, int) {
+	columns, lines, current := 0, 1, 0
+	for _, r := range text {
+		if r == '\n' {
+			lines++
+			current = 0
+			continue
+		}
+		current++
+		if current > columns {
+			columns = current
+		}
+	}
+	return columns, lines
+}
+
+func (t *TextRenderer) addGlyph(r rune, x, y, width, height float32) {
+	u0, v0, u1, v1 := t.font.glyphTexCoords(r)
+	offset := uint32(len(t.vertices) / 4)
+	t.vertices = append(t.vertices,
+		x, y, u0, v1,
+		x, y+height, u0, v0,
+		x+width, y+height, u1, v0,
+		x+width, y, u1, v1,
+	)
+	t.indices = append(t.indices,
+		offset, offset+1, offset+2,
+		offset, offset+2, offset+3,
+	)
+}
+
+// Delete releases the mesh, the shader program and the font.
+func (t *TextRenderer) Delete() {
+	t.mesh.Delete()
+	t.shaderProgram.Delete()
+	t.font.Delete()
+}
@@ -0,0 +1,45 @@
+package renderer
+
+import (
+	"fmt"
+
+	"github.com/devodev/opengl-experiment/internal/opengl"
+)
+
+// TextureRegion is a rectangle of a texture, such as
+// a cell of a sprite sheet.
+type TextureRegion struct {
+	texture *opengl.Texture
+	// bottom left and top right texture coordinates
+	u0, v0 float32
+	u1, v1 float32
+}
+
+// NewTextureRegion selects the width by height pixels rectangle at x
+// and y, from the top left corner of the texture image.
+func NewTextureRegion(texture *opengl.Texture, x, y, width, height int) (*TextureRegion, error) {
+	textureWidth, textureHeight := texture.GetSize()
+	if x < 0 || y < 0 || width <= 0 || height <= 0 || x+width > textureWidth || y+height > textureHeight {
+		return nil, fmt.Errorf("texture region out of bounds: %dx%d at %d,%d != %dx%d", width, height, x, y, textureWidth, textureHeight)
+	}
+	// images are flipped vertically on upload
+	region := &TextureRegion{
+		texture: texture,
+		u0:      float32(x) / float32(textureWidth),
+		u1:      float32(x+width) / float32(textureWidth),
+		v0:      1 - float32(y+height)/float32(textureHeight),
+		v1:      1 - float32(y)/float32(textureHeight),
+	}
+	return region, nil
+}
+
+// GetTexture .
+func (r *TextureRegion) GetTexture() *opengl.Texture {
+	return r.texture
+}
+
+// GetTexCoords returns the bottom left and
+// top right texture coordinates.
+func (r *TextureRegion) GetTexCoords() (u0, v0, u1, v1 float32) {
+	return r.u0, r.v0, r.u1, r.v1
+}
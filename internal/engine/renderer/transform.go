@@ -0,0 +1,91 @@
+package renderer
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Transform places an object in the world.
+type Transform struct {
+	position mgl32.Vec3
+	rotation mgl32.Quat
+	scale    mgl32.Vec3
+}
+
+// NewTransform returns the identity transform.
+func NewTransform() *Transform {
+	return &Transform{
+		rotation: mgl32.QuatIdent(),
+		scale:    mgl32.Vec3{1, 1, 1},
+	}
+}
+
+// GetMatrix returns the model matrix, applying
+// scale, then rotation, then translation.
+func (t *Transform) GetMatrix() mgl32.Mat4 {
+	translation := mgl32.Translate3D(t.position.X(), t.position.Y(), t.position.Z())
+	scale := mgl32.Scale3D(t.scale.X(), t.scale.Y(), t.scale.Z())
+	return translation.Mul4(t.rotation.Mat4()).Mul4(scale)
+}
+
+// NormalMatrix returns the inverse transpose of the upper left 3x3
+// of the model matrix, transforming normals so that they stay
+// perpendicular to surfaces under non-uniform scale.
+func NormalMatrix(model mgl32.Mat4) mgl32.Mat3 {
+	return model.Mat3().Inv().Transpose()
+}
+
+// GetPosition .
+func (t *Transform) GetPosition() mgl32.Vec3 {
+	return t.position
+}
+
+// SetPosition .
+func (t *Transform) SetPosition(position mgl32.Vec3) {
+	t.position = position
+}
+
+// Translate .
+func (t *Transform) Translate(offset mgl32.Vec3) {
+	t.position = t.position.Add(offset)
+}
+
+// GetRotation .
+func (t *Transform) GetRotation() mgl32.Quat {
+	return t.rotation
+}
+
+// SetRotation .
+func (t *Transform) SetRotation(rotation mgl32.Quat) {
+	t.rotation = rotation.Normalize()
+}
+
+// Rotate rotates by angle radians around axis,
+// on top of the current rotation.
+func (t *Transform) Rotate(angle float32, axis mgl32.Vec3) {
+	t.rotation = mgl32.QuatRotate(angle, axis.Normalize()).Mul(t.rotation).Normalize()
+}
+
+// RotateX .
+func (t *Transform) RotateX(angle float32) {
+	t.Rotate(angle, mgl32.Vec3{1, 0, 0})
+}
+
+// RotateY .
+func (t *Transform) RotateY(angle float32) {
+	t.Rotate(angle, mgl32.Vec3{0, 1, 0})
+}
+
+// RotateZ .
+func (t *Transform) RotateZ(angle float32) {
+	t.Rotate(angle, mgl32.Vec3{0, 0, 1})
+}
+
+// GetScale .
+func (t *Transform) GetScale() mgl32.Vec3 {
+	return t.scale
+}
+
+// SetScale .
+func (t *Transform) SetScale(scale mgl32.Vec3) {
+	t.scale = scale
+}
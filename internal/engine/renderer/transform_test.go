@@ -0,0 +1,25 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestNormalMatrix(t *testing.T) {
+	cases := []struct {
+		name  string
+		model mgl32.Mat4
+		want  mgl32.Mat3
+	}{
+		{"identity", mgl32.Ident4(), mgl32.Ident3()},
+		{"non-uniform scale", mgl32.Scale3D(2, 1, 1), mgl32.Diag3(mgl32.Vec3{0.5, 1, 1})},
+		{"translation is ignored", mgl32.Translate3D(1, 2, 3), mgl32.Ident3()},
+		{"rotation is preserved", mgl32.HomogRotate3DY(mgl32.DegToRad(90)), mgl32.Rotate3DY(mgl32.DegToRad(90))},
+	}
+	for _, c := range cases {
+		if got := NormalMatrix(c.model); !got.ApproxEqualThreshold(c.want, 1e-6) {
+			t.Errorf("%s: NormalMatrix() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package renderer
+
+import "github.com/devodev/opengl-experiment/internal/opengl"
+
+// The vertex color shader expects positions (vec3) at location 0
+// and colors (vec4) at location 1, transformed by model and vp (mat4).
+const (
+	vertexColorVertexShader = `
+#version 460 core
+layout (location = 0) in vec3 position;
+layout (location = 1) in vec4 color;
+
+out vec4 fragColor;
+
+uniform mat4 model;
+uniform mat4 vp;
+
+void main() {
+    fragColor = color;
+    gl_Position = vp * model * vec4(position, 1.0);
+}
+    `
+
+	vertexColorFragmentShader = `
+#version 460 core
+layout (location = 0) out vec4 outColor;
+
+in vec4 fragColor;
+
+void main() {
+    outColor = fragColor;
+}
+    `
+)
+
+// NewVertexColorLayout returns the layout of meshes colored per
+// vertex: position (vec3) at location 0 and color (vec4, RGBA)
+// at location 1, for a stride of 28 bytes.
+func NewVertexColorLayout() *opengl.VBOLayout {
+	return opengl.NewVBOLayout(
+		opengl.VBOLayoutElement{Count: 3, Normalized: false, DataType: opengl.GLDataTypeFloat},
+		opengl.VBOLayoutElement{Count: 4, Normalized: false, DataType: opengl.GLDataTypeFloat},
+	)
+}
+
+// NewVertexColorProgram returns a program drawing meshes using
+// NewVertexColorLayout with their colors interpolated between
+// vertices, without textures or lighting. It uses the "model"
+// and "vp" uniforms, like scene graph nodes expect.
+func NewVertexColorProgram() (*opengl.ShaderProgram, error) {
+	return opengl.NewShaderProgram(vertexColorVertexShader+"\x00", vertexColorFragmentShader+"\x00")
+}
@@ -0,0 +1,54 @@
+package renderer
+
+import "github.com/go-gl/gl/v4.6-core/gl"
+
+// Viewport is a region of the current viewport, in pixels from
+// its top left corner to the top left corner of the region.
+type Viewport struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// AspectRatio returns the aspect ratio to render the region
+// with, 1 when empty.
+func (v Viewport) AspectRatio() float32 {
+	if v.Width <= 0 || v.Height <= 0 {
+		return 1
+	}
+	return float32(v.Width) / float32(v.Height)
+}
+
+// RenderViewports calls render once per viewport, with the OpenGL
+// viewport and scissor box set to the region, ex: for split-screen.
+// Cameras must be resized to the dimensions of the viewport in render
+// for the projection to match its aspect ratio. Calling Clear from
+// render only clears the region. The viewport and the scissor
+// test are restored afterwards.
+func (r *Renderer) RenderViewports(viewports []Viewport, render func(v Viewport)) {
+	var full [4]int32
+	gl.GetIntegerv(gl.VIEWPORT, &full[0])
+	var scissorBox [4]int32
+	gl.GetIntegerv(gl.SCISSOR_BOX, &scissorBox[0])
+	scissorTest := r.scissorTest
+	r.renderingViewports = true
+	defer func() {
+		r.renderingViewports = false
+		gl.Viewport(full[0], full[1], full[2], full[3])
+		gl.Scissor(scissorBox[0], scissorBox[1], scissorBox[2], scissorBox[3])
+		if !scissorTest {
+			r.DisableScissor()
+		}
+	}()
+
+	for _, v := range viewports {
+		// OpenGL window coordinates start at the bottom left corner
+		bottom := full[1] + full[3] - int32(v.Y+v.Height)
+		gl.Viewport(full[0]+int32(v.X), bottom, int32(v.Width), int32(v.Height))
+		// keep primitives larger than the viewport, ex: wide points and
+		// lines, from spilling over the neighbouring regions
+		r.SetScissor(0, 0, v.Width, v.Height)
+		render(v)
+	}
+}
@@ -0,0 +1,323 @@
+package window
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Action names used by the engine, bound in DefaultActionMap.
+const (
+	ActionQuit             = "quit"
+	ActionForward          = "forward"
+	ActionBackward         = "backward"
+	ActionLeft             = "left"
+	ActionRight            = "right"
+	ActionRotate           = "rotate"
+	ActionCaptureCursor    = "capture_cursor"
+	ActionToggleWireframe  = "toggle_wireframe"
+	ActionToggleVSync      = "toggle_vsync"
+	ActionToggleCulling    = "toggle_culling"
+	ActionToggleProjection = "toggle_projection"
+	ActionToggleFullscreen = "toggle_fullscreen"
+	ActionReloadShaders    = "reload_shaders"
+)
+
+// Binding is either a key or a mouse button.
+type Binding struct {
+	Key         Key
+	MouseButton MouseButton
+	IsMouse     bool
+}
+
+// KeyBinding .
+func KeyBinding(key Key) Binding {
+	return Binding{Key: key}
+}
+
+// MouseButtonBinding .
+func MouseButtonBinding(button MouseButton) Binding {
+	return Binding{MouseButton: button, IsMouse: true}
+}
+
+// String returns the name used by ActionMap.ToMap.
+func (b Binding) String() string {
+	if b.IsMouse {
+		return mouseButtonNames[b.MouseButton]
+	}
+	return keyNames[b.Key]
+}
+
+// ParseBinding parses a binding name, such as "W",
+// "Escape" or "MouseButton1".
+func ParseBinding(name string) (Binding, error) {
+	for button, buttonName := range mouseButtonNames {
+		if buttonName == name {
+			return MouseButtonBinding(button), nil
+		}
+	}
+	for key, keyName := range keyNames {
+		if keyName == name {
+			return KeyBinding(key), nil
+		}
+	}
+	return Binding{}, fmt.Errorf("unknown binding: %q", name)
+}
+
+// ActionMap maps action names to the keys and mouse
+// buttons triggering them, so that controls can be remapped.
+type ActionMap struct {
+	bindings map[string][]Binding
+}
+
+// NewActionMap .
+func NewActionMap() *ActionMap {
+	return &ActionMap{bindings: make(map[string][]Binding)}
+}
+
+// DefaultActionMap returns the bindings of the engine actions.
+func DefaultActionMap() *ActionMap {
+	m := NewActionMap()
+	m.Bind(ActionQuit, KeyBinding(KeyEscape))
+	m.Bind(ActionForward, KeyBinding(KeyW))
+	m.Bind(ActionBackward, KeyBinding(KeyS))
+	m.Bind(ActionLeft, KeyBinding(KeyA))
+	m.Bind(ActionRight, KeyBinding(KeyD))
+	m.Bind(ActionRotate, MouseButtonBinding(MouseButton1))
+	m.Bind(ActionCaptureCursor, MouseButtonBinding(MouseButton2))
+	m.Bind(ActionToggleWireframe, KeyBinding(KeyF1))
+	m.Bind(ActionToggleVSync, KeyBinding(KeyF2))
+	m.Bind(ActionToggleCulling, KeyBinding(KeyF3))
+	m.Bind(ActionToggleProjection, KeyBinding(KeyF4))
+	m.Bind(ActionReloadShaders, KeyBinding(KeyF5))
+	m.Bind(ActionToggleFullscreen, KeyBinding(KeyF11))
+	return m
+}
+
+// LoadActionMap creates an action map from binding names
+// per action, as returned by ToMap.
+func LoadActionMap(actions map[string][]string) (*ActionMap, error) {
+	m := NewActionMap()
+	for action, names := range actions {
+		bindings := make([]Binding, 0, len(names))
+		for _, name := range names {
+			binding, err := ParseBinding(name)
+			if err != nil {
+				return nil, fmt.Errorf("error loading action %q: %s", action, err)
+			}
+			bindings = append(bindings, binding)
+		}
+		m.Bind(action, bindings...)
+	}
+	return m, nil
+}
+
+// ToMap returns the binding names per action, to be persisted
+// and loaded back with LoadActionMap.
+func (m *ActionMap) ToMap() map[string][]string {
+	actions := make(map[string][]string, len(m.bindings))
+	for action, bindings := range m.bindings {
+		names := make([]string, 0, len(bindings))
+		for _, binding := range bindings {
+			names = append(names, binding.String())
+		}
+		actions[action] = names
+	}
+	return actions
+}
+
+// Bind replaces the bindings of the action.
+func (m *ActionMap) Bind(action string, bindings ...Binding) {
+	m.bindings[action] = append([]Binding(nil), bindings...)
+}
+
+// AddBinding adds a binding to the action.
+func (m *ActionMap) AddBinding(action string, binding Binding) {
+	m.bindings[action] = append(m.bindings[action], binding)
+}
+
+// Unbind removes all bindings of the action.
+func (m *ActionMap) Unbind(action string) {
+	delete(m.bindings, action)
+}
+
+// GetBindings .
+func (m *ActionMap) GetBindings(action string) []Binding {
+	return m.bindings[action]
+}
+
+// GetActions returns the sorted names of the bound actions.
+func (m *ActionMap) GetActions() []string {
+	actions := make([]string, 0, len(m.bindings))
+	for action := range m.bindings {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	return actions
+}
+
+// IsActionActive returns true while any binding of the action is held down.
+func (w *Window) IsActionActive(action string) bool {
+	for _, binding := range w.actions.bindings[action] {
+		if binding.IsMouse && w.IsMouseButtonDown(binding.MouseButton) ||
+			!binding.IsMouse && w.IsKeyDown(binding.Key) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActionPressed returns true if any binding of the action was
+// pressed during the last PollEvents, use it for one-shot actions.
+func (w *Window) IsActionPressed(action string) bool {
+	for _, binding := range w.actions.bindings[action] {
+		if binding.IsMouse && w.IsMouseButtonPressed(binding.MouseButton) ||
+			!binding.IsMouse && w.IsKeyPressed(binding.Key) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetActionMap .
+func (w *Window) GetActionMap() *ActionMap {
+	return w.actions
+}
+
+// SetActionMap .
+func (w *Window) SetActionMap(actions *ActionMap) {
+	w.actions = actions
+}
+
+var keyNames = map[Key]string{
+	KeySpace:        "Space",
+	KeyApostrophe:   "Apostrophe",
+	KeyComma:        "Comma",
+	KeyMinus:        "Minus",
+	KeyPeriod:       "Period",
+	KeySlash:        "Slash",
+	Key0:            "0",
+	Key1:            "1",
+	Key2:            "2",
+	Key3:            "3",
+	Key4:            "4",
+	Key5:            "5",
+	Key6:            "6",
+	Key7:            "7",
+	Key8:            "8",
+	Key9:            "9",
+	KeySemicolon:    "Semicolon",
+	KeyEqual:        "Equal",
+	KeyA:            "A",
+	KeyB:            "B",
+	KeyC:            "C",
+	KeyD:            "D",
+	KeyE:            "E",
+	KeyF:            "F",
+	KeyG:            "G",
+	KeyH:            "H",
+	KeyI:            "I",
+	KeyJ:            "J",
+	KeyK:            "K",
+	KeyL:            "L",
+	KeyM:            "M",
+	KeyN:            "N",
+	KeyO:            "O",
+	KeyP:            "P",
+	KeyQ:            "Q",
+	KeyR:            "R",
+	KeyS:            "S",
+	KeyT:            "T",
+	KeyU:            "U",
+	KeyV:            "V",
+	KeyW:            "W",
+	KeyX:            "X",
+	KeyY:            "Y",
+	KeyZ:            "Z",
+	KeyLeftBracket:  "LeftBracket",
+	KeyBackslash:    "Backslash",
+	KeyRightBracket: "RightBracket",
+	KeyGraveAccent:  "GraveAccent",
+	KeyWorld1:       "World1",
+	KeyWorld2:       "World2",
+	KeyEscape:       "Escape",
+	KeyEnter:        "Enter",
+	KeyTab:          "Tab",
+	KeyBackspace:    "Backspace",
+	KeyInsert:       "Insert",
+	KeyDelete:       "Delete",
+	KeyRight:        "Right",
+	KeyLeft:         "Left",
+	KeyDown:         "Down",
+	KeyUp:           "Up",
+	KeyPageUp:       "PageUp",
+	KeyPageDown:     "PageDown",
+	KeyHome:         "Home",
+	KeyEnd:          "End",
+	KeyCapsLock:     "CapsLock",
+	KeyScrollLock:   "ScrollLock",
+	KeyNumLock:      "NumLock",
+	KeyPrintScreen:  "PrintScreen",
+	KeyPause:        "Pause",
+	KeyF1:           "F1",
+	KeyF2:           "F2",
+	KeyF3:           "F3",
+	KeyF4:           "F4",
+	KeyF5:           "F5",
+	KeyF6:           "F6",
+	KeyF7:           "F7",
+	KeyF8:           "F8",
+	KeyF9:           "F9",
+	KeyF10:          "F10",
+	KeyF11:          "F11",
+	KeyF12:          "F12",
+	KeyF13:          "F13",
+	KeyF14:          "F14",
+	KeyF15:          "F15",
+	KeyF16:          "F16",
+	KeyF17:          "F17",
+	KeyF18:          "F18",
+	KeyF19:          "F19",
+	KeyF20:          "F20",
+	KeyF21:          "F21",
+	KeyF22:          "F22",
+	KeyF23:          "F23",
+	KeyF24:          "F24",
+	KeyF25:          "F25",
+	KeyKP0:          "KP0",
+	KeyKP1:          "KP1",
+	KeyKP2:          "KP2",
+	KeyKP3:          "KP3",
+	KeyKP4:          "KP4",
+	KeyKP5:          "KP5",
+	KeyKP6:          "KP6",
+	KeyKP7:          "KP7",
+	KeyKP8:          "KP8",
+	KeyKP9:          "KP9",
+	KeyKPDecimal:    "KPDecimal",
+	KeyKPDivide:     "KPDivide",
+	KeyKPMultiply:   "KPMultiply",
+	KeyKPSubtract:   "KPSubtract",
+	KeyKPAdd:        "KPAdd",
+	KeyKPEnter:      "KPEnter",
+	KeyKPEqual:      "KPEqual",
+	KeyLeftShift:    "LeftShift",
+	KeyLeftControl:  "LeftControl",
+	KeyLeftAlt:      "LeftAlt",
+	KeyLeftSuper:    "LeftSuper",
+	KeyRightShift:   "RightShift",
+	KeyRightControl: "RightControl",
+	KeyRightAlt:     "RightAlt",
+	KeyRightSuper:   "RightSuper",
+	KeyMenu:         "Menu",
+}
+
+var mouseButtonNames = map[MouseButton]string{
+	MouseButton1: "MouseButton1",
+	MouseButton2: "MouseButton2",
+	MouseButton3: "MouseButton3",
+	MouseButton4: "MouseButton4",
+	MouseButton5: "MouseButton5",
+	MouseButton6: "MouseButton6",
+	MouseButton7: "MouseButton7",
+	MouseButton8: "MouseButton8",
+}
@@ -0,0 +1,12 @@
+package window
+
+// GetClipboard returns the clipboard content, or an empty
+// string when it does not contain text.
+func (w *Window) GetClipboard() string {
+	return w.window.GetClipboardString()
+}
+
+// SetClipboard .
+func (w *Window) SetClipboard(text string) {
+	w.window.SetClipboardString(text)
+}
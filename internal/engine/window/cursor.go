@@ -0,0 +1,51 @@
+package window
+
+import (
+	"fmt"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// CursorMode .
+type CursorMode int
+
+// Cursor modes
+const (
+	CursorNormal CursorMode = iota
+	// CursorHidden hides the cursor while it is over the window
+	CursorHidden
+	// CursorDisabled hides and locks the cursor to the window,
+	// its position being unbounded, for mouse-look controls
+	CursorDisabled
+)
+
+// SetCursorMode also enables raw mouse motion, when supported,
+// while the cursor is disabled.
+func (w *Window) SetCursorMode(mode CursorMode) error {
+	var value int
+	switch mode {
+	case CursorNormal:
+		value = glfw.CursorNormal
+	case CursorHidden:
+		value = glfw.CursorHidden
+	case CursorDisabled:
+		value = glfw.CursorDisabled
+	default:
+		return fmt.Errorf("invalid cursor mode: %d", mode)
+	}
+	w.window.SetInputMode(glfw.CursorMode, value)
+	if glfw.RawMouseMotionSupported() {
+		if mode == CursorDisabled {
+			w.window.SetInputMode(glfw.RawMouseMotion, glfw.True)
+		} else {
+			w.window.SetInputMode(glfw.RawMouseMotion, glfw.False)
+		}
+	}
+	w.cursorMode = mode
+	return nil
+}
+
+// GetCursorMode .
+func (w *Window) GetCursorMode() CursorMode {
+	return w.cursorMode
+}
@@ -0,0 +1,114 @@
+package window
+
+import (
+	"math"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+var (
+	defaultGamepadDeadzone = float32(0.15)
+)
+
+// GamepadAxis represents a gamepad analog axis.
+type GamepadAxis int
+
+// glfw gamepad axis mapping.
+const (
+	GamepadAxisLeftX        = GamepadAxis(glfw.AxisLeftX)
+	GamepadAxisLeftY        = GamepadAxis(glfw.AxisLeftY)
+	GamepadAxisRightX       = GamepadAxis(glfw.AxisRightX)
+	GamepadAxisRightY       = GamepadAxis(glfw.AxisRightY)
+	GamepadAxisLeftTrigger  = GamepadAxis(glfw.AxisLeftTrigger)
+	GamepadAxisRightTrigger = GamepadAxis(glfw.AxisRightTrigger)
+)
+
+// GamepadButton represents a gamepad button.
+type GamepadButton int
+
+// glfw gamepad button mapping.
+const (
+	GamepadButtonA           = GamepadButton(glfw.ButtonA)
+	GamepadButtonB           = GamepadButton(glfw.ButtonB)
+	GamepadButtonX           = GamepadButton(glfw.ButtonX)
+	GamepadButtonY           = GamepadButton(glfw.ButtonY)
+	GamepadButtonLeftBumper  = GamepadButton(glfw.ButtonLeftBumper)
+	GamepadButtonRightBumper = GamepadButton(glfw.ButtonRightBumper)
+	GamepadButtonBack        = GamepadButton(glfw.ButtonBack)
+	GamepadButtonStart       = GamepadButton(glfw.ButtonStart)
+	GamepadButtonGuide       = GamepadButton(glfw.ButtonGuide)
+	GamepadButtonLeftThumb   = GamepadButton(glfw.ButtonLeftThumb)
+	GamepadButtonRightThumb  = GamepadButton(glfw.ButtonRightThumb)
+	GamepadButtonDpadUp      = GamepadButton(glfw.ButtonDpadUp)
+	GamepadButtonDpadRight   = GamepadButton(glfw.ButtonDpadRight)
+	GamepadButtonDpadDown    = GamepadButton(glfw.ButtonDpadDown)
+	GamepadButtonDpadLeft    = GamepadButton(glfw.ButtonDpadLeft)
+)
+
+// Gamepad is a joystick with a standard gamepad mapping.
+// Its state is updated by Window.PollEvents.
+type Gamepad struct {
+	joystick glfw.Joystick
+	state    glfw.GamepadState
+	deadzone float32
+}
+
+func newGamepad(joystick glfw.Joystick) *Gamepad {
+	g := &Gamepad{
+		joystick: joystick,
+		deadzone: defaultGamepadDeadzone,
+	}
+	g.update()
+	return g
+}
+
+// GetName .
+func (g *Gamepad) GetName() string {
+	return g.joystick.GetGamepadName()
+}
+
+// SetDeadzone sets the fraction of the stick range around
+// the center that is reported as 0.
+func (g *Gamepad) SetDeadzone(deadzone float32) {
+	g.deadzone = deadzone
+}
+
+// GetAxis returns a value in [-1, 1] for sticks, Y pointing
+// down, and in [0, 1] for triggers.
+func (g *Gamepad) GetAxis(axis GamepadAxis) float32 {
+	value := g.state.Axes[axis]
+	if axis == GamepadAxisLeftTrigger || axis == GamepadAxisRightTrigger {
+		return (value + 1) / 2
+	}
+	magnitude := float32(math.Abs(float64(value)))
+	if magnitude < g.deadzone {
+		return 0
+	}
+	// rescale so that values start from 0 past the deadzone
+	rescaled := (magnitude - g.deadzone) / (1 - g.deadzone)
+	if value < 0 {
+		return -rescaled
+	}
+	return rescaled
+}
+
+// IsButtonDown .
+func (g *Gamepad) IsButtonDown(button GamepadButton) bool {
+	return g.state.Buttons[button] == glfw.Press
+}
+
+func (g *Gamepad) update() {
+	if state := g.joystick.GetGamepadState(); state != nil {
+		g.state = *state
+	}
+}
+
+// findGamepad returns the first connected gamepad, or nil.
+func findGamepad() *Gamepad {
+	for joystick := glfw.Joystick1; joystick <= glfw.JoystickLast; joystick++ {
+		if joystick.IsGamepad() {
+			return newGamepad(joystick)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package window
+
+import (
+	"fmt"
+	"image"
+	"os"
+
+	// window icons are usually PNG files
+	_ "image/png"
+)
+
+// SetIcon sets the window icon from image files of different sizes,
+// the OS picking the closest to the one it needs. Files that cannot
+// be decoded are skipped with a warning. It has no effect on macOS.
+func (w *Window) SetIcon(paths ...string) error {
+	images := make([]image.Image, 0, len(paths))
+	for _, path := range paths {
+		img, err := decodeIcon(path)
+		if err != nil {
+			fmt.Printf("[GLFW WARNING] skipping window icon: %s\n", err)
+			continue
+		}
+		images = append(images, img)
+	}
+	if len(images) == 0 {
+		return fmt.Errorf("no valid window icon in %q", paths)
+	}
+	w.window.SetIcon(images)
+	return nil
+}
+
+func decodeIcon(path string) (image.Image, error) {
+	reader, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading icon file %q: %s", path, err)
+	}
+	defer reader.Close()
+
+	img, _, err := image.Decode(reader)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding icon file %q: %s", path, err)
+	}
+	return img, nil
+}
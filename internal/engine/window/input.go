@@ -149,3 +149,56 @@ const (
 	MouseButtonRight  MouseButton = MouseButton(glfw.MouseButtonRight)
 	MouseButtonMiddle MouseButton = MouseButton(glfw.MouseButtonMiddle)
 )
+
+// inputState tracks the keys and mouse buttons whose
+// state changed during the last PollEvents.
+type inputState struct {
+	keysPressed          map[Key]bool
+	keysReleased         map[Key]bool
+	mouseButtonsPressed  map[MouseButton]bool
+	mouseButtonsReleased map[MouseButton]bool
+
+	// text input accumulates until consumed
+	typedText  []rune
+	backspaces int
+}
+
+func newInputState() *inputState {
+	s := &inputState{}
+	s.reset()
+	return s
+}
+
+func (s *inputState) reset() {
+	s.keysPressed = make(map[Key]bool)
+	s.keysReleased = make(map[Key]bool)
+	s.mouseButtonsPressed = make(map[MouseButton]bool)
+	s.mouseButtonsReleased = make(map[MouseButton]bool)
+}
+
+func (s *inputState) onKey(key Key, action glfw.Action) {
+	switch action {
+	case glfw.Press:
+		s.keysPressed[key] = true
+	case glfw.Release:
+		s.keysReleased[key] = true
+	}
+	// backspace produces no character, repeats
+	// erase as many characters as a held key would
+	if key == KeyBackspace && action != glfw.Release {
+		s.backspaces++
+	}
+}
+
+func (s *inputState) onChar(char rune) {
+	s.typedText = append(s.typedText, char)
+}
+
+func (s *inputState) onMouseButton(button MouseButton, action glfw.Action) {
+	switch action {
+	case glfw.Press:
+		s.mouseButtonsPressed[button] = true
+	case glfw.Release:
+		s.mouseButtonsReleased[button] = true
+	}
+}
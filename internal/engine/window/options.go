@@ -1,5 +1,7 @@
 package window
 
+import "fmt"
+
 // Option .
 type Option func(*Window) error
 
@@ -19,3 +21,116 @@ func WithTitleOption(title string) Option {
 		return nil
 	}
 }
+
+// WithVSyncOption .
+func WithVSyncOption(enabled bool) Option {
+	return func(w *Window) error {
+		w.vsync = enabled
+		return nil
+	}
+}
+
+// WithSamplesOption sets the number of samples used
+// for multisample anti-aliasing, 0 disables it.
+func WithSamplesOption(samples int) Option {
+	return func(w *Window) error {
+		if samples < 0 {
+			return fmt.Errorf("invalid number of samples: %d", samples)
+		}
+		w.samples = samples
+		return nil
+	}
+}
+
+// WithStencilBitsOption sets the number of bits of the stencil
+// buffer requested through the GLFW_STENCIL_BITS window hint,
+// 0 disables it. Stencil operations of the renderer need it.
+func WithStencilBitsOption(bits int) Option {
+	return func(w *Window) error {
+		if bits < 0 || bits > 8 {
+			return fmt.Errorf("invalid number of stencil bits: %d != 0 <= x <= 8", bits)
+		}
+		w.stencil = bits
+		return nil
+	}
+}
+
+// WithSRGBOption requests an sRGB capable default framebuffer
+// through the GLFW_SRGB_CAPABLE window hint, enabled by default.
+func WithSRGBOption(enabled bool) Option {
+	return func(w *Window) error {
+		w.srgb = enabled
+		return nil
+	}
+}
+
+// WithContextVersionOption sets the requested OpenGL context
+// version, 4.6 by default. Creating the window fails if it is
+// not supported.
+func WithContextVersionOption(major, minor int) Option {
+	return func(w *Window) error {
+		if major < 1 || minor < 0 {
+			return fmt.Errorf("invalid OpenGL context version: %d.%d", major, minor)
+		}
+		w.contextMajor = major
+		w.contextMinor = minor
+		return nil
+	}
+}
+
+// WithContextProfileOption requests a core profile context, the
+// default, or a compatibility one. Forward compatible contexts drop
+// deprecated features, both are always enabled on macOS.
+func WithContextProfileOption(core, forwardCompatible bool) Option {
+	return func(w *Window) error {
+		w.coreProfile = core
+		w.forwardCompatible = forwardCompatible
+		return nil
+	}
+}
+
+// WithIconOption sets the window icon from image
+// files of different sizes, see Window.SetIcon.
+func WithIconOption(paths ...string) Option {
+	return func(w *Window) error {
+		w.icons = paths
+		return nil
+	}
+}
+
+// WithActionMapOption replaces DefaultActionMap.
+func WithActionMapOption(actions *ActionMap) Option {
+	return func(w *Window) error {
+		if actions == nil {
+			return fmt.Errorf("action map is nil")
+		}
+		w.actions = actions
+		return nil
+	}
+}
+
+// WithModeOption .
+func WithModeOption(mode Mode) Option {
+	return func(w *Window) error {
+		switch mode {
+		case ModeWindowed, ModeFullscreen, ModeBorderlessFullscreen:
+		default:
+			return fmt.Errorf("invalid window mode: %d", mode)
+		}
+		w.mode = mode
+		return nil
+	}
+}
+
+// WithMonitorOption selects the monitor used in fullscreen modes,
+// by index in ListMonitors. In ModeWindowed, the window is
+// centered on it. The primary monitor is used if it is not connected.
+func WithMonitorOption(index int) Option {
+	return func(w *Window) error {
+		if index < 0 {
+			return fmt.Errorf("invalid monitor index: %d", index)
+		}
+		w.monitor = index
+		return nil
+	}
+}
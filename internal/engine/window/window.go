@@ -2,6 +2,7 @@ package window
 
 import (
 	"fmt"
+	"runtime"
 
 	"github.com/go-gl/gl/v4.6-core/gl"
 	"github.com/go-gl/glfw/v3.3/glfw"
@@ -10,8 +11,22 @@ import (
 var (
 	glfwMajorVersion            = 4
 	glfwMinorVersion            = 6
-	glfwOpenGLCoreProfile       = glfw.OpenGLCoreProfile
-	glfwOpenGLForwardCompatible = glfw.True
+	glfwOpenGLCoreProfile       = true
+	glfwOpenGLForwardCompatible = true
+)
+
+// Mode .
+type Mode int
+
+// Window modes
+const (
+	ModeWindowed Mode = iota
+	// ModeFullscreen takes exclusive ownership of the
+	// monitor, at the resolution of its video mode.
+	ModeFullscreen
+	// ModeBorderlessFullscreen covers the monitor using
+	// its current video mode, making mode switches fast.
+	ModeBorderlessFullscreen
 )
 
 var (
@@ -19,6 +34,15 @@ var (
 	defaultWindowHeight    = 768
 	defaultWindowTitle     = "Application"
 	defaultWindowResizable = true
+	defaultWindowVSync     = true
+	defaultWindowSamples   = 4
+	// the classic object outline technique needs a stencil buffer
+	defaultWindowStencilBits = 8
+	// sRGB encoding is only done when the renderer enables it
+	defaultWindowSRGB = true
+	defaultWindowMode = ModeWindowed
+	// use the primary monitor and let the OS place the window
+	defaultWindowMonitor = -1
 )
 
 // Window .
@@ -27,10 +51,59 @@ type Window struct {
 	height    int
 	title     string
 	resizable bool
+	vsync     bool
+	samples   int
+	stencil   int
+	srgb      bool
+	mode      Mode
+	monitor   int
+	icons     []string
+
+	cursorMode CursorMode
+	// updated by the focus callback
+	focused bool
+
+	// requested OpenGL context
+	contextMajor      int
+	contextMinor      int
+	coreProfile       bool
+	forwardCompatible bool
+
+	// windowed position and dimensions,
+	// restored when leaving fullscreen
+	windowedX      int
+	windowedY      int
+	windowedWidth  int
+	windowedHeight int
+
+	// framebuffer dimensions are in pixels and can differ
+	// from the window dimensions on high-DPI monitors
+	framebufferWidth  int
+	framebufferHeight int
+
+	// scroll offsets accumulated during the last PollEvents
+	scrollX        float64
+	scrollY        float64
+	scrollHandlers []ScrollHandler
+
+	// paths dropped during PollEvents, dispatched once it returns
+	droppedPaths [][]string
+	dropHandlers []DropHandler
+
+	input   *inputState
+	actions *ActionMap
+	gamepad *Gamepad
 
 	window *glfw.Window
 }
 
+// ScrollHandler receives the scroll offsets of
+// each scroll event, y being the vertical wheel.
+type ScrollHandler func(xOffset, yOffset float64)
+
+// DropHandler receives the paths of the files dropped on the window.
+type DropHandler func(paths []string)
+
 // New .
 func New(options ...Option) (*Window, error) {
 	window := &Window{
@@ -38,6 +111,19 @@ func New(options ...Option) (*Window, error) {
 		height:    defaultWindowHeight,
 		title:     defaultWindowTitle,
 		resizable: defaultWindowResizable,
+		vsync:     defaultWindowVSync,
+		samples:   defaultWindowSamples,
+		stencil:   defaultWindowStencilBits,
+		srgb:      defaultWindowSRGB,
+		mode:      defaultWindowMode,
+		monitor:   defaultWindowMonitor,
+		input:     newInputState(),
+		actions:   DefaultActionMap(),
+
+		contextMajor:      glfwMajorVersion,
+		contextMinor:      glfwMinorVersion,
+		coreProfile:       glfwOpenGLCoreProfile,
+		forwardCompatible: glfwOpenGLForwardCompatible,
 	}
 
 	for _, opt := range options {
@@ -54,42 +140,396 @@ func (w *Window) Init() error {
 		return fmt.Errorf("error initializing GLFW: %s", err)
 	}
 
-	glfw.WindowHint(glfw.ContextVersionMajor, glfwMajorVersion)
-	glfw.WindowHint(glfw.ContextVersionMinor, glfwMinorVersion)
-	glfw.WindowHint(glfw.OpenGLProfile, glfwOpenGLCoreProfile)
-	glfw.WindowHint(glfw.OpenGLForwardCompatible, glfwOpenGLForwardCompatible)
+	w.setContextHints()
 	if w.resizable {
 		glfw.WindowHint(glfw.Resizable, glfw.True)
 	} else {
 		glfw.WindowHint(glfw.Resizable, glfw.False)
 	}
+	// number of samples used for multisample anti-aliasing,
+	// 0 disables it
+	glfw.WindowHint(glfw.Samples, w.samples)
+	// bits of the default framebuffer stencil buffer,
+	// 0 creates the window without one
+	glfw.WindowHint(glfw.StencilBits, w.stencil)
+	// allow the default framebuffer to encode linear colors to sRGB
+	if w.srgb {
+		glfw.WindowHint(glfw.SRGBCapable, glfw.True)
+	} else {
+		glfw.WindowHint(glfw.SRGBCapable, glfw.False)
+	}
+
+	w.windowedWidth, w.windowedHeight = w.width, w.height
 
 	// create a window
-	window, err := glfw.CreateWindow(w.width, w.height, w.title, nil, nil)
+	var monitor *glfw.Monitor
+	width, height := w.width, w.height
+	if w.mode != ModeWindowed {
+		monitor = w.getMonitor()
+		// where the window goes when switching to ModeWindowed,
+		// there is no windowed position to save yet
+		w.windowedX, w.windowedY = centeredPos(monitor, w.windowedWidth, w.windowedHeight)
+		videoMode := monitor.GetVideoMode()
+		width, height = videoMode.Width, videoMode.Height
+		glfw.WindowHint(glfw.RefreshRate, videoMode.RefreshRate)
+		if w.mode == ModeBorderlessFullscreen {
+			glfw.WindowHint(glfw.RedBits, videoMode.RedBits)
+			glfw.WindowHint(glfw.GreenBits, videoMode.GreenBits)
+			glfw.WindowHint(glfw.BlueBits, videoMode.BlueBits)
+		}
+	}
+	window, err := glfw.CreateWindow(width, height, w.title, monitor, nil)
 	if err != nil {
-		return fmt.Errorf("error creating window: %s", err)
+		return fmt.Errorf("error creating window with an OpenGL %s context: %s", w.contextDescription(), err)
 	}
 	w.window = window
+	if w.mode == ModeWindowed {
+		if w.monitor != defaultWindowMonitor {
+			w.centerOnMonitor(w.getMonitor())
+		}
+		w.windowedX, w.windowedY = w.window.GetPos()
+	}
 	w.window.MakeContextCurrent()
 
-	// set window resize callback
-	if w.resizable {
-		w.window.SetFramebufferSizeCallback(func(window *glfw.Window, width int, height int) {
-			gl.Viewport(0, 0, int32(width), int32(height))
-		})
+	if len(w.icons) > 0 {
+		if err := w.SetIcon(w.icons...); err != nil {
+			fmt.Printf("[GLFW WARNING] %s\n", err)
+		}
 	}
 
+	w.width, w.height = w.window.GetSize()
+	w.framebufferWidth, w.framebufferHeight = w.window.GetFramebufferSize()
+
+	// set window resize callbacks
+	w.window.SetSizeCallback(func(window *glfw.Window, width int, height int) {
+		w.width = width
+		w.height = height
+	})
+	w.window.SetFramebufferSizeCallback(func(window *glfw.Window, width int, height int) {
+		w.framebufferWidth = width
+		w.framebufferHeight = height
+		gl.Viewport(0, 0, int32(width), int32(height))
+	})
+
+	// GLFW callbacks are invoked from PollEvents on the main thread
+	w.window.SetScrollCallback(func(window *glfw.Window, xOffset float64, yOffset float64) {
+		w.scrollX += xOffset
+		w.scrollY += yOffset
+		for _, handler := range w.scrollHandlers {
+			handler(xOffset, yOffset)
+		}
+	})
+
+	w.focused = w.window.GetAttrib(glfw.Focused) == glfw.True
+	w.window.SetFocusCallback(func(window *glfw.Window, focused bool) {
+		w.focused = focused
+	})
+
+	w.window.SetDropCallback(func(window *glfw.Window, names []string) {
+		w.droppedPaths = append(w.droppedPaths, names)
+	})
+
+	w.window.SetKeyCallback(func(window *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+		w.input.onKey(Key(key), action)
+	})
+	w.window.SetCharCallback(func(window *glfw.Window, char rune) {
+		w.input.onChar(char)
+	})
+	w.window.SetMouseButtonCallback(func(window *glfw.Window, button glfw.MouseButton, action glfw.Action, mods glfw.ModifierKey) {
+		w.input.onMouseButton(MouseButton(button), action)
+	})
+
+	// joystick events are global, only the first
+	// connected gamepad is tracked
+	w.gamepad = findGamepad()
+	glfw.SetJoystickCallback(func(joystick glfw.Joystick, event glfw.PeripheralEvent) {
+		switch event {
+		case glfw.Connected:
+			if w.gamepad == nil && joystick.IsGamepad() {
+				w.gamepad = newGamepad(joystick)
+			}
+		case glfw.Disconnected:
+			if w.gamepad != nil && w.gamepad.joystick == joystick {
+				w.gamepad = findGamepad()
+			}
+		}
+	})
+
 	// sync with monitor refresh rate
-	glfw.SwapInterval(1)
+	// *the swap interval applies to the current context,
+	// so this must happen after `MakeContextCurrent()`
+	w.SetVSync(w.vsync)
 
 	return nil
 }
 
+// SetMode switches between windowed and fullscreen modes,
+// restoring the previous windowed position and dimensions
+// when going back to ModeWindowed.
+func (w *Window) SetMode(mode Mode) {
+	if mode == w.mode {
+		return
+	}
+	if w.mode == ModeWindowed {
+		w.windowedX, w.windowedY = w.window.GetPos()
+		w.windowedWidth, w.windowedHeight = w.window.GetSize()
+	}
+	w.mode = mode
+
+	if w.mode == ModeWindowed {
+		w.window.SetMonitor(nil, w.windowedX, w.windowedY, w.windowedWidth, w.windowedHeight, 0)
+	} else {
+		monitor := w.getMonitor()
+		videoMode := monitor.GetVideoMode()
+		w.window.SetMonitor(monitor, 0, 0, videoMode.Width, videoMode.Height, videoMode.RefreshRate)
+	}
+	// the swap interval may be reset when the monitor changes
+	w.SetVSync(w.vsync)
+}
+
+// GetMode .
+func (w *Window) GetMode() Mode {
+	return w.mode
+}
+
+// ListMonitors returns the connected monitors, the first one
+// being the primary monitor. It must be called after Init.
+func ListMonitors() []*glfw.Monitor {
+	return glfw.GetMonitors()
+}
+
+// getMonitor falls back to the primary monitor when
+// the requested one is not connected.
+func (w *Window) getMonitor() *glfw.Monitor {
+	if w.monitor == defaultWindowMonitor {
+		return glfw.GetPrimaryMonitor()
+	}
+	monitors := ListMonitors()
+	if w.monitor >= len(monitors) {
+		fmt.Printf("[GLFW WARNING] monitor %d not found (%d connected), using primary monitor\n", w.monitor, len(monitors))
+		return glfw.GetPrimaryMonitor()
+	}
+	return monitors[w.monitor]
+}
+
+func (w *Window) centerOnMonitor(monitor *glfw.Monitor) {
+	width, height := w.window.GetSize()
+	w.window.SetPos(centeredPos(monitor, width, height))
+}
+
+// centeredPos returns the position of a window
+// of that size centered on the monitor.
+func centeredPos(monitor *glfw.Monitor, width, height int) (int, int) {
+	monitorX, monitorY := monitor.GetPos()
+	videoMode := monitor.GetVideoMode()
+	return monitorX + (videoMode.Width-width)/2, monitorY + (videoMode.Height-height)/2
+}
+
+// SetVSync .
+func (w *Window) SetVSync(enabled bool) {
+	w.vsync = enabled
+	if w.vsync {
+		glfw.SwapInterval(1)
+	} else {
+		glfw.SwapInterval(0)
+	}
+}
+
+// IsVSync .
+func (w *Window) IsVSync() bool {
+	return w.vsync
+}
+
 // GetSize .
 func (w *Window) GetSize() (int, int) {
 	return w.width, w.height
 }
 
+// SetSize resizes the client area of a windowed mode window,
+// GetSize is updated once the resize event is received.
+func (w *Window) SetSize(width, height int) error {
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("invalid window dimensions: %dx%d", width, height)
+	}
+	w.window.SetSize(width, height)
+	return nil
+}
+
+// GetPos returns the position of the top left corner of the
+// client area, in screen coordinates of the virtual desktop.
+func (w *Window) GetPos() (int, int) {
+	return w.window.GetPos()
+}
+
+// SetPos .
+func (w *Window) SetPos(x, y int) {
+	w.window.SetPos(x, y)
+}
+
+// Center centers a windowed mode window on the monitor
+// it currently occupies the most.
+func (w *Window) Center() {
+	if w.mode != ModeWindowed {
+		return
+	}
+	w.centerOnMonitor(w.currentMonitor())
+}
+
+// currentMonitor returns the monitor overlapping the window
+// the most, the primary monitor when it is off screen.
+func (w *Window) currentMonitor() *glfw.Monitor {
+	x, y := w.window.GetPos()
+	width, height := w.window.GetSize()
+
+	current := glfw.GetPrimaryMonitor()
+	bestArea := 0
+	for _, monitor := range ListMonitors() {
+		monitorX, monitorY := monitor.GetPos()
+		videoMode := monitor.GetVideoMode()
+		overlapX := min(x+width, monitorX+videoMode.Width) - max(x, monitorX)
+		overlapY := min(y+height, monitorY+videoMode.Height) - max(y, monitorY)
+		if overlapX > 0 && overlapY > 0 && overlapX*overlapY > bestArea {
+			bestArea = overlapX * overlapY
+			current = monitor
+		}
+	}
+	return current
+}
+
+// setContextHints requests the OpenGL context version and profile.
+// Profiles only exist since OpenGL 3.2 and macOS only
+// provides forward compatible core contexts past 2.1.
+func (w *Window) setContextHints() {
+	glfw.WindowHint(glfw.ContextVersionMajor, w.contextMajor)
+	glfw.WindowHint(glfw.ContextVersionMinor, w.contextMinor)
+	if runtime.GOOS == "darwin" && w.contextMajor >= 3 {
+		w.coreProfile = true
+		w.forwardCompatible = true
+	}
+	profile := glfw.OpenGLAnyProfile
+	if w.contextMajor > 3 || (w.contextMajor == 3 && w.contextMinor >= 2) {
+		profile = glfw.OpenGLCompatProfile
+		if w.coreProfile {
+			profile = glfw.OpenGLCoreProfile
+		}
+	}
+	glfw.WindowHint(glfw.OpenGLProfile, profile)
+	if w.forwardCompatible {
+		glfw.WindowHint(glfw.OpenGLForwardCompatible, glfw.True)
+	} else {
+		glfw.WindowHint(glfw.OpenGLForwardCompatible, glfw.False)
+	}
+}
+
+func (w *Window) contextDescription() string {
+	description := fmt.Sprintf("%d.%d", w.contextMajor, w.contextMinor)
+	if w.coreProfile {
+		description += " core"
+	}
+	if w.forwardCompatible {
+		description += " forward compatible"
+	}
+	return description
+}
+
+// GetStencilBits returns the number of stencil bits
+// requested when the window was created.
+func (w *Window) GetStencilBits() int {
+	return w.stencil
+}
+
+// GetSamples returns the number of samples
+// requested for multisample anti-aliasing.
+func (w *Window) GetSamples() int {
+	return w.samples
+}
+
+// GetTitle returns the title the window was created with.
+func (w *Window) GetTitle() string {
+	return w.title
+}
+
+// SetTitle sets the title displayed by the window
+// without changing the one returned by GetTitle.
+func (w *Window) SetTitle(title string) {
+	w.window.SetTitle(title)
+}
+
+// GetFramebufferSize .
+func (w *Window) GetFramebufferSize() (int, int) {
+	return w.framebufferWidth, w.framebufferHeight
+}
+
+// PollEvents processes pending events,
+// invoking the registered callbacks.
+func (w *Window) PollEvents() {
+	w.processEvents(glfw.PollEvents)
+}
+
+// WaitEvents is like PollEvents but sleeps until an event is
+// received or timeout seconds elapsed, when there are none pending.
+func (w *Window) WaitEvents(timeout float64) {
+	w.processEvents(func() {
+		glfw.WaitEventsTimeout(timeout)
+	})
+}
+
+func (w *Window) processEvents(process func()) {
+	w.scrollX = 0
+	w.scrollY = 0
+	w.input.reset()
+	process()
+	if w.gamepad != nil {
+		w.gamepad.update()
+	}
+	w.dispatchDroppedPaths()
+}
+
+// dispatchDroppedPaths calls the drop handlers outside of GLFW
+// callbacks, so that they are free to load assets.
+func (w *Window) dispatchDroppedPaths() {
+	dropped := w.droppedPaths
+	w.droppedPaths = nil
+	for _, paths := range dropped {
+		for _, handler := range w.dropHandlers {
+			handler(paths)
+		}
+	}
+}
+
+// SwapBuffers .
+func (w *Window) SwapBuffers() {
+	w.window.SwapBuffers()
+}
+
+// GetGamepad returns the first connected gamepad,
+// or nil if there is none.
+func (w *Window) GetGamepad() *Gamepad {
+	return w.gamepad
+}
+
+// IsGamepadConnected .
+func (w *Window) IsGamepadConnected() bool {
+	return w.gamepad != nil
+}
+
+// GetScrollOffset returns the scroll offsets
+// accumulated during the last PollEvents.
+func (w *Window) GetScrollOffset() (float64, float64) {
+	return w.scrollX, w.scrollY
+}
+
+// AddScrollHandler .
+func (w *Window) AddScrollHandler(handler ScrollHandler) {
+	w.scrollHandlers = append(w.scrollHandlers, handler)
+}
+
+// AddDropHandler registers a handler called from PollEvents, on the
+// main thread, with the paths of each group of files dropped on the window.
+func (w *Window) AddDropHandler(handler DropHandler) {
+	w.dropHandlers = append(w.dropHandlers, handler)
+}
+
 // ShouldClose .
 func (w *Window) ShouldClose() bool {
 	return w.window.ShouldClose()
@@ -97,19 +537,60 @@ func (w *Window) ShouldClose() bool {
 
 // IsFocused .
 func (w *Window) IsFocused() bool {
-	return w.window.GetAttrib(glfw.Focused) == glfw.True
+	return w.focused
 }
 
-// IsKeyPressed .
-func (w *Window) IsKeyPressed(key Key) bool {
+// IsKeyDown returns true while the key is held down.
+func (w *Window) IsKeyDown(key Key) bool {
 	return w.window.GetKey(glfw.Key(key)) == glfw.Press
 }
 
-// IsMouseButtonPressed .
-func (w *Window) IsMouseButtonPressed(m MouseButton) bool {
+// IsKeyPressed returns true if the key was pressed during
+// the last PollEvents, use it for one-shot actions.
+func (w *Window) IsKeyPressed(key Key) bool {
+	return w.input.keysPressed[key]
+}
+
+// IsKeyReleased returns true if the key was
+// released during the last PollEvents.
+func (w *Window) IsKeyReleased(key Key) bool {
+	return w.input.keysReleased[key]
+}
+
+// IsMouseButtonDown returns true while the button is held down.
+func (w *Window) IsMouseButtonDown(m MouseButton) bool {
 	return w.window.GetMouseButton(glfw.MouseButton(m)) == glfw.Press
 }
 
+// IsMouseButtonPressed returns true if the button
+// was pressed during the last PollEvents.
+func (w *Window) IsMouseButtonPressed(m MouseButton) bool {
+	return w.input.mouseButtonsPressed[m]
+}
+
+// IsMouseButtonReleased returns true if the button
+// was released during the last PollEvents.
+func (w *Window) IsMouseButtonReleased(m MouseButton) bool {
+	return w.input.mouseButtonsReleased[m]
+}
+
+// ConsumeTypedText returns the text typed since the last call,
+// following the keyboard layout of the user. Backspace is not
+// part of it, see ConsumeBackspaces.
+func (w *Window) ConsumeTypedText() string {
+	text := string(w.input.typedText)
+	w.input.typedText = w.input.typedText[:0]
+	return text
+}
+
+// ConsumeBackspaces returns the number of times backspace was
+// pressed or repeated since the last call.
+func (w *Window) ConsumeBackspaces() int {
+	backspaces := w.input.backspaces
+	w.input.backspaces = 0
+	return backspaces
+}
+
 // GetCursorPos .
 func (w *Window) GetCursorPos() (float64, float64) {
 	return w.window.GetCursorPos()
@@ -117,6 +598,10 @@ func (w *Window) GetCursorPos() (float64, float64) {
 
 // Close .
 func (w *Window) Close() error {
+	if w.window != nil {
+		w.window.Destroy()
+		w.window = nil
+	}
 	glfw.Terminate()
 	return nil
 }
@@ -125,3 +610,17 @@ func (w *Window) Close() error {
 func (w *Window) GetGLFWWindow() *glfw.Window {
 	return w.window
 }
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
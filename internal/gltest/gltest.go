@@ -0,0 +1,93 @@
+// Package gltest provides a hidden OpenGL context to the tests
+// needing a GPU. Those tests are built with the gltest tag:
+//
+//	go test -tags gltest ./...
+//
+// and are skipped when no context can be created, ex: without a display.
+package gltest
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+var (
+	// window owning the context, nil when it could not be created
+	window *glfw.Window
+	// reason the context could not be created
+	contextError error
+)
+
+func init() {
+	// GLFW must be initialized from the main thread,
+	// which runs the init functions.
+	runtime.LockOSThread()
+}
+
+// Main creates the context and runs the tests, it must be called
+// from TestMain. It does not return.
+func Main(m *testing.M) {
+	contextError = createContext()
+	code := m.Run()
+	if window != nil {
+		window.Destroy()
+		glfw.Terminate()
+	}
+	os.Exit(code)
+}
+
+func createContext() (err error) {
+	// GLFW only logs platform errors, ex: a missing display,
+	// then panics on the next call
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("error initializing GLFW: %v", r)
+		}
+	}()
+	if err := glfw.Init(); err != nil {
+		return fmt.Errorf("error initializing GLFW: %s", err)
+	}
+	glfw.WindowHint(glfw.Visible, glfw.False)
+	glfw.WindowHint(glfw.ContextVersionMajor, 4)
+	glfw.WindowHint(glfw.ContextVersionMinor, 6)
+	glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLCoreProfile)
+	glfw.WindowHint(glfw.OpenGLForwardCompatible, glfw.True)
+	w, err := glfw.CreateWindow(64, 64, "gltest", nil, nil)
+	if err != nil {
+		glfw.Terminate()
+		return fmt.Errorf("error creating window: %s", err)
+	}
+	w.MakeContextCurrent()
+	if err := gl.Init(); err != nil {
+		w.Destroy()
+		glfw.Terminate()
+		return fmt.Errorf("error initializing OpenGL: %s", err)
+	}
+	// tests make it current on their own thread
+	glfw.DetachCurrentContext()
+	window = w
+	return nil
+}
+
+// Context makes the context current for the calling test or
+// benchmark, skipping it when there is none. Tests using it
+// must not run in parallel.
+func Context(tb testing.TB) {
+	tb.Helper()
+	if window == nil {
+		tb.Skipf("no OpenGL context: %s", contextError)
+	}
+	// tests run on their own goroutine, keep it on
+	// the thread the context is current on
+	runtime.LockOSThread()
+	window.MakeContextCurrent()
+	tb.Cleanup(func() {
+		glfw.DetachCurrentContext()
+		runtime.UnlockOSThread()
+	})
+}
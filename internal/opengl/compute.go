@@ -0,0 +1,25 @@
+package opengl
+
+import "github.com/go-gl/gl/v4.6-core/gl"
+
+// NewComputeShaderProgram requires that the compute
+// shader source be a null terminated string.
+func NewComputeShaderProgram(computeShaderSource string) (*ShaderProgram, error) {
+	return newShaderProgram(
+		shaderStage{name: "compute", shaderType: gl.COMPUTE_SHADER, source: computeShaderSource},
+	)
+}
+
+// Dispatch runs the compute shader program over x*y*z work groups.
+// When barriers is non-zero, gl.MemoryBarrier is called with it
+// so that the results are visible to subsequent commands,
+// ex: gl.SHADER_STORAGE_BARRIER_BIT.
+func (s *ShaderProgram) Dispatch(x, y, z uint32, barriers uint32) {
+	s.Bind()
+	defer s.Unbind()
+
+	gl.DispatchCompute(x, y, z)
+	if barriers != 0 {
+		gl.MemoryBarrier(barriers)
+	}
+}
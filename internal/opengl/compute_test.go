@@ -0,0 +1,51 @@
+//go:build gltest
+// +build gltest
+
+package opengl
+
+import (
+	"testing"
+
+	"github.com/devodev/opengl-experiment/internal/gltest"
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+const doubleComputeShader = `
+#version 460 core
+layout (local_size_x = 4) in;
+
+layout (std430, binding = 0) buffer Data {
+    float values[];
+};
+
+void main() {
+    values[gl_GlobalInvocationID.x] *= 2.0;
+}
+` + "\x00"
+
+func TestDispatch(t *testing.T) {
+	gltest.Context(t)
+
+	program, err := NewComputeShaderProgram(doubleComputeShader)
+	if err != nil {
+		t.Fatalf("error creating compute shader program: %s", err)
+	}
+	defer program.Delete()
+	ssbo, err := NewSSBO([]float32{1, 2, 3, 4, 5, 6, 7, 8}, 0)
+	if err != nil {
+		t.Fatalf("error creating SSBO: %s", err)
+	}
+	defer ssbo.Delete()
+
+	program.Dispatch(2, 1, 1, gl.SHADER_STORAGE_BARRIER_BIT|gl.BUFFER_UPDATE_BARRIER_BIT)
+
+	out := make([]float32, 8)
+	if err := ssbo.Read(out); err != nil {
+		t.Fatalf("error reading SSBO: %s", err)
+	}
+	for i, v := range out {
+		if want := float32(2 * (i + 1)); v != want {
+			t.Errorf("values[%d] = %f, want %f", i, v, want)
+		}
+	}
+}
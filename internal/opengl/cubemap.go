@@ -0,0 +1,114 @@
+package opengl
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// Cubemap faces order expected by NewCubemap.
+const (
+	CubemapFaceRight = iota
+	CubemapFaceLeft
+	CubemapFaceTop
+	CubemapFaceBottom
+	CubemapFaceFront
+	CubemapFaceBack
+)
+
+// Cubemap .
+type Cubemap struct {
+	id          uint32
+	index       int
+	textureUnit uint32
+}
+
+// NewCubemap loads the faces in the CubemapFace order. Unlike
+// 2D textures, faces are not flipped vertically: cubemaps
+// follow the RenderMan convention where the origin of each
+// face is its top left corner.
+func NewCubemap(faces [6]string, index int) (*Cubemap, error) {
+	if index < 0 {
+		return nil, fmt.Errorf("texture target out of bounds: %d != 0 <= x", index)
+	}
+	images := make([]*image.NRGBA, len(faces))
+	for idx, face := range faces {
+		img, err := decodeImageFile(face)
+		if err != nil {
+			return nil, err
+		}
+		images[idx] = imaging.Clone(img)
+
+		size := images[idx].Rect.Size()
+		if size.X != size.Y {
+			return nil, fmt.Errorf("cubemap face %q is not square: %dx%d", face, size.X, size.Y)
+		}
+		if first := images[0].Rect.Size(); size != first {
+			return nil, fmt.Errorf("cubemap face %q size mismatch: %dx%d != %dx%d", face, size.X, size.Y, first.X, first.Y)
+		}
+	}
+
+	var id uint32
+	gl.GenTextures(1, &id)
+
+	cubemap := &Cubemap{
+		id:          id,
+		index:       index,
+		textureUnit: uint32(gl.TEXTURE0 + index),
+	}
+	cubemap.Bind()
+	defer cubemap.Unbind()
+
+	gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.TEXTURE_WRAP_R, gl.CLAMP_TO_EDGE)
+	for idx, img := range images {
+		gl.TexImage2D(
+			uint32(gl.TEXTURE_CUBE_MAP_POSITIVE_X+idx),
+			0,
+			gl.RGBA8,
+			int32(img.Rect.Size().X),
+			int32(img.Rect.Size().Y),
+			0,
+			gl.RGBA,
+			gl.UNSIGNED_BYTE,
+			gl.Ptr(img.Pix),
+		)
+	}
+	track(cubemap)
+	return cubemap, nil
+}
+
+// Bind .
+func (c *Cubemap) Bind() {
+	gl.ActiveTexture(c.textureUnit)
+	gl.BindTexture(gl.TEXTURE_CUBE_MAP, c.id)
+}
+
+// Unbind .
+func (c *Cubemap) Unbind() {
+	gl.ActiveTexture(c.textureUnit)
+	gl.BindTexture(gl.TEXTURE_CUBE_MAP, 0)
+}
+
+// GetID .
+func (c *Cubemap) GetID() uint32 {
+	return c.id
+}
+
+// GetIndex .
+func (c *Cubemap) GetIndex() int {
+	return c.index
+}
+
+// Delete .
+func (c *Cubemap) Delete() {
+	untrack(c)
+	gl.DeleteTextures(1, &c.id)
+	c.id = 0
+}
@@ -0,0 +1,131 @@
+package opengl
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+var (
+	debugMessageLineLength = 90
+)
+
+// EnableDebugOutput registers a debug message callback printing
+// every message reported by the driver. Messages are reported
+// synchronously, so that they are printed from the offending call.
+//
+// Notification severity messages are skipped unless
+// notifications is true.
+func EnableDebugOutput(notifications bool) {
+	gl.Enable(gl.DEBUG_OUTPUT)
+	gl.Enable(gl.DEBUG_OUTPUT_SYNCHRONOUS)
+	gl.DebugMessageCallback(func(
+		source uint32,
+		gltype uint32,
+		id uint32,
+		severity uint32,
+		length int32,
+		message string,
+		userParam unsafe.Pointer) {
+
+		if severity == gl.DEBUG_SEVERITY_NOTIFICATION && !notifications {
+			return
+		}
+
+		fmt.Println("[OpenGL DEBUG]")
+		fmt.Printf("[OpenGL DEBUG]\tsource (0x%x): %v\n", source, strings.Join(GlEnums[source], ", "))
+		fmt.Printf("[OpenGL DEBUG]\tgltype (0x%x): %v\n", gltype, strings.Join(GlEnums[gltype], ", "))
+		fmt.Printf("[OpenGL DEBUG]\tseverity (0x%x): %v\n", severity, strings.Join(GlEnums[severity], ", "))
+		fmt.Printf("[OpenGL DEBUG]\tid: %v\n", id)
+		fmt.Println("[OpenGL DEBUG]\tmessage:")
+		msgIdx := 0
+		for msgIdx < len(message) {
+			fmt.Printf("[OpenGL DEBUG]\t\t%v\n", strings.TrimLeft(message[msgIdx:min(msgIdx+debugMessageLineLength, len(message))], "  "))
+			msgIdx += debugMessageLineLength
+		}
+		fmt.Println("[OpenGL DEBUG]")
+	}, nil)
+}
+
+// DisableDebugOutput .
+func DisableDebugOutput() {
+	gl.Disable(gl.DEBUG_OUTPUT_SYNCHRONOUS)
+	gl.Disable(gl.DEBUG_OUTPUT)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+var glErrorNames = map[uint32]string{
+	gl.INVALID_ENUM:                  "GL_INVALID_ENUM",
+	gl.INVALID_VALUE:                 "GL_INVALID_VALUE",
+	gl.INVALID_OPERATION:             "GL_INVALID_OPERATION",
+	gl.STACK_OVERFLOW:                "GL_STACK_OVERFLOW",
+	gl.STACK_UNDERFLOW:               "GL_STACK_UNDERFLOW",
+	gl.OUT_OF_MEMORY:                 "GL_OUT_OF_MEMORY",
+	gl.INVALID_FRAMEBUFFER_OPERATION: "GL_INVALID_FRAMEBUFFER_OPERATION",
+	gl.CONTEXT_LOST:                  "GL_CONTEXT_LOST",
+}
+
+// CheckGLError drains the OpenGL error queue and returns
+// the errors found prefixed by context, or nil if there were none.
+func CheckGLError(context string) error {
+	var errs []string
+	for {
+		code := gl.GetError()
+		if code == gl.NO_ERROR {
+			break
+		}
+		name, ok := glErrorNames[code]
+		if !ok {
+			name = fmt.Sprintf("0x%x", code)
+		}
+		errs = append(errs, name)
+		// a lost context keeps reporting GL_CONTEXT_LOST
+		if code == gl.CONTEXT_LOST {
+			break
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s: %s", context, strings.Join(errs, ", "))
+}
+
+// DebugChecks enables CheckDrawState, release builds
+// leave it disabled to skip the state queries.
+var DebugChecks = false
+
+// CheckDrawState returns an error prefixed by context when the
+// state is not ready for a draw call: no VAO or no valid shader
+// program or pipeline bound. It does nothing unless DebugChecks is enabled.
+func CheckDrawState(context string) error {
+	if !DebugChecks {
+		return nil
+	}
+	var vao int32
+	gl.GetIntegerv(gl.VERTEX_ARRAY_BINDING, &vao)
+	if vao == 0 {
+		return fmt.Errorf("%s: drawing without a bound VAO", context)
+	}
+	var program int32
+	gl.GetIntegerv(gl.CURRENT_PROGRAM, &program)
+	if program == 0 {
+		// separable programs are used through a pipeline
+		var pipeline int32
+		gl.GetIntegerv(gl.PROGRAM_PIPELINE_BINDING, &pipeline)
+		if pipeline != 0 && gl.IsProgramPipeline(uint32(pipeline)) {
+			return nil
+		}
+	}
+	if program == 0 || !gl.IsProgram(uint32(program)) {
+		return fmt.Errorf("%s: drawing without a valid shader program bound: %d", context, program)
+	}
+	return nil
+}
@@ -0,0 +1,357 @@
+package opengl
+
+import (
+	"fmt"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// Framebuffer renders offscreen into one or more color
+// textures backed by a depth/stencil renderbuffer, or
+// texture when it needs to be sampled.
+//
+// A multisampled framebuffer uses renderbuffers for all its
+// attachments instead, it can not be sampled and must be
+// resolved into a single sampled framebuffer with ResolveTo.
+type Framebuffer struct {
+	id          uint32
+	width       int
+	height      int
+	samples     int
+	colorFormat int32
+
+	colorTextureIDs      []uint32
+	colorRenderbufferIDs []uint32
+	depthID              uint32
+	depthTexture         bool
+}
+
+// NewFramebuffer creates a framebuffer with a single color texture.
+func NewFramebuffer(width, height int) (*Framebuffer, error) {
+	return NewFramebufferMRT(width, height, 1)
+}
+
+// FramebufferOptions zero values select the defaults: no color
+// attachment, RGBA8 color textures when ColorCount is set.
+type FramebufferOptions struct {
+	// ColorCount is the number of color textures, attached at
+	// GL_COLOR_ATTACHMENT0 onwards. A framebuffer without any,
+	// ex: for shadow maps, only renders depth and stencil.
+	ColorCount int
+	// ColorFormat is the internal format of the color textures:
+	// gl.RGBA8, or gl.RGBA16F and gl.RGBA32F which are not clamped
+	// to [0, 1]. HDR colors must be tonemapped, ex: with a
+	// post-processing pass, before reaching the default framebuffer.
+	ColorFormat int32
+	// DepthTexture attaches the depth/stencil buffer as a texture
+	// instead of a renderbuffer, so that shaders can sample the depth
+	DepthTexture bool
+	// Samples is the number of samples per pixel of a multisampled
+	// framebuffer, ex: 4 for 4x MSAA, up to GL_MAX_SAMPLES.
+	// 0 or 1 creates a single sampled framebuffer.
+	Samples int
+}
+
+func (o FramebufferOptions) validate() (FramebufferOptions, error) {
+	if o.ColorFormat == 0 {
+		o.ColorFormat = gl.RGBA8
+	}
+	var maxDrawBuffers int32
+	gl.GetIntegerv(gl.MAX_DRAW_BUFFERS, &maxDrawBuffers)
+	if o.ColorCount < 0 || o.ColorCount > int(maxDrawBuffers) {
+		return o, fmt.Errorf("invalid framebuffer color attachment count: %d != 0 <= x <= %d", o.ColorCount, maxDrawBuffers)
+	}
+	switch o.ColorFormat {
+	case gl.RGBA8, gl.RGBA16F, gl.RGBA32F:
+	default:
+		return o, fmt.Errorf("invalid framebuffer color format: 0x%x", o.ColorFormat)
+	}
+	if o.Samples < 0 {
+		return o, fmt.Errorf("invalid framebuffer sample count: %d", o.Samples)
+	}
+	if o.Samples > 1 {
+		var maxSamples int32
+		gl.GetIntegerv(gl.MAX_SAMPLES, &maxSamples)
+		if o.Samples > int(maxSamples) {
+			return o, fmt.Errorf("invalid framebuffer sample count: %d > GL_MAX_SAMPLES %d", o.Samples, maxSamples)
+		}
+		if o.DepthTexture {
+			return o, fmt.Errorf("multisampled framebuffer can not have a depth texture, resolve it first")
+		}
+	}
+	return o, nil
+}
+
+// NewFramebufferMRT creates a framebuffer with colorCount color
+// textures attached at GL_COLOR_ATTACHMENT0 onwards, all written
+// to by fragment shaders declaring as many outputs, ex: a G-buffer.
+func NewFramebufferMRT(width, height int, colorCount int) (*Framebuffer, error) {
+	return NewFramebufferWithOptions(width, height, FramebufferOptions{ColorCount: colorCount})
+}
+
+// NewMultisampleFramebuffer creates a framebuffer with a single
+// multisampled color attachment, ex: to render an anti-aliased scene
+// which is then resolved with ResolveTo.
+func NewMultisampleFramebuffer(width, height int, samples int) (*Framebuffer, error) {
+	return NewFramebufferWithOptions(width, height, FramebufferOptions{ColorCount: 1, Samples: samples})
+}
+
+// NewDepthFramebuffer creates a framebuffer without color
+// attachment and with a depth texture, ex: for shadow maps.
+func NewDepthFramebuffer(width, height int) (*Framebuffer, error) {
+	return NewFramebufferWithOptions(width, height, FramebufferOptions{DepthTexture: true})
+}
+
+// NewFramebufferWithOptions .
+func NewFramebufferWithOptions(width, height int, opts FramebufferOptions) (*Framebuffer, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid framebuffer dimensions: %dx%d", width, height)
+	}
+	opts, err := opts.validate()
+	if err != nil {
+		return nil, err
+	}
+	colorCount := opts.ColorCount
+	// the pixel type only matters when uploading data
+	pixelType := uint32(gl.UNSIGNED_BYTE)
+	if opts.ColorFormat != gl.RGBA8 {
+		pixelType = gl.FLOAT
+	}
+	var fboID uint32
+	gl.GenFramebuffers(1, &fboID)
+	fbo := &Framebuffer{
+		id:           fboID,
+		width:        width,
+		height:       height,
+		colorFormat:  opts.ColorFormat,
+		depthTexture: opts.DepthTexture,
+	}
+
+	// not using Bind, which would leave the viewport
+	// set to the framebuffer dimensions
+	gl.BindFramebuffer(gl.FRAMEBUFFER, fbo.id)
+	defer gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+
+	if opts.Samples > 1 {
+		fbo.samples = opts.Samples
+		fbo.attachMultisampleBuffers(colorCount)
+		return fbo.checkStatus()
+	}
+
+	fbo.colorTextureIDs = make([]uint32, colorCount)
+	if colorCount > 0 {
+		gl.GenTextures(int32(colorCount), &fbo.colorTextureIDs[0])
+	}
+	attachments := make([]uint32, colorCount)
+	for i, textureID := range fbo.colorTextureIDs {
+		gl.BindTexture(gl.TEXTURE_2D, textureID)
+		gl.TexImage2D(gl.TEXTURE_2D, 0, opts.ColorFormat, int32(width), int32(height), 0, gl.RGBA, pixelType, nil)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+		gl.BindTexture(gl.TEXTURE_2D, 0)
+		attachments[i] = gl.COLOR_ATTACHMENT0 + uint32(i)
+		gl.FramebufferTexture2D(gl.FRAMEBUFFER, attachments[i], gl.TEXTURE_2D, textureID, 0)
+	}
+	setColorBuffers(attachments)
+
+	if fbo.depthTexture {
+		gl.GenTextures(1, &fbo.depthID)
+		gl.BindTexture(gl.TEXTURE_2D, fbo.depthID)
+		gl.TexImage2D(gl.TEXTURE_2D, 0, gl.DEPTH24_STENCIL8, int32(width), int32(height), 0, gl.DEPTH_STENCIL, gl.UNSIGNED_INT_24_8, nil)
+		// sample the depth rather than the stencil index
+		gl.TexParameteri(gl.TEXTURE_2D, gl.DEPTH_STENCIL_TEXTURE_MODE, gl.DEPTH_COMPONENT)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.NEAREST)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.NEAREST)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+		gl.BindTexture(gl.TEXTURE_2D, 0)
+		gl.FramebufferTexture2D(gl.FRAMEBUFFER, gl.DEPTH_STENCIL_ATTACHMENT, gl.TEXTURE_2D, fbo.depthID, 0)
+	} else {
+		gl.GenRenderbuffers(1, &fbo.depthID)
+		gl.BindRenderbuffer(gl.RENDERBUFFER, fbo.depthID)
+		gl.RenderbufferStorage(gl.RENDERBUFFER, gl.DEPTH24_STENCIL8, int32(width), int32(height))
+		gl.BindRenderbuffer(gl.RENDERBUFFER, 0)
+		gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.DEPTH_STENCIL_ATTACHMENT, gl.RENDERBUFFER, fbo.depthID)
+	}
+	return fbo.checkStatus()
+}
+
+// attachMultisampleBuffers attaches multisampled color and
+// depth/stencil renderbuffers to the bound framebuffer.
+func (f *Framebuffer) attachMultisampleBuffers(colorCount int) {
+	samples := int32(f.samples)
+	f.colorRenderbufferIDs = make([]uint32, colorCount)
+	if colorCount > 0 {
+		gl.GenRenderbuffers(int32(colorCount), &f.colorRenderbufferIDs[0])
+	}
+	attachments := make([]uint32, colorCount)
+	for i, renderbufferID := range f.colorRenderbufferIDs {
+		gl.BindRenderbuffer(gl.RENDERBUFFER, renderbufferID)
+		gl.RenderbufferStorageMultisample(gl.RENDERBUFFER, samples, uint32(f.colorFormat), int32(f.width), int32(f.height))
+		attachments[i] = gl.COLOR_ATTACHMENT0 + uint32(i)
+		gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, attachments[i], gl.RENDERBUFFER, renderbufferID)
+	}
+	setColorBuffers(attachments)
+
+	gl.GenRenderbuffers(1, &f.depthID)
+	gl.BindRenderbuffer(gl.RENDERBUFFER, f.depthID)
+	gl.RenderbufferStorageMultisample(gl.RENDERBUFFER, samples, gl.DEPTH24_STENCIL8, int32(f.width), int32(f.height))
+	gl.BindRenderbuffer(gl.RENDERBUFFER, 0)
+	gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.DEPTH_STENCIL_ATTACHMENT, gl.RENDERBUFFER, f.depthID)
+}
+
+// setColorBuffers selects the attachments fragment shaders write
+// to, and reads from the first one. Without color attachments, both
+// must be GL_NONE for the framebuffer to be complete.
+func setColorBuffers(attachments []uint32) {
+	if len(attachments) == 0 {
+		gl.DrawBuffer(gl.NONE)
+		gl.ReadBuffer(gl.NONE)
+		return
+	}
+	gl.DrawBuffers(int32(len(attachments)), &attachments[0])
+	gl.ReadBuffer(attachments[0])
+}
+
+// checkStatus deletes the bound framebuffer if it is incomplete.
+func (f *Framebuffer) checkStatus() (*Framebuffer, error) {
+	if status := gl.CheckFramebufferStatus(gl.FRAMEBUFFER); status != gl.FRAMEBUFFER_COMPLETE {
+		gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+		f.Delete()
+		return nil, fmt.Errorf("framebuffer incomplete (0x%x)", status)
+	}
+	track(f)
+	return f, nil
+}
+
+// Bind also sets the viewport to the framebuffer dimensions.
+func (f *Framebuffer) Bind() {
+	gl.BindFramebuffer(gl.FRAMEBUFFER, f.id)
+	gl.Viewport(0, 0, int32(f.width), int32(f.height))
+}
+
+// Unbind restores the default framebuffer. The viewport
+// must be restored by the caller.
+func (f *Framebuffer) Unbind() {
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+}
+
+// GetColorTexture returns the ID of the first color attachment
+// texture, or 0 when the framebuffer is multisampled or has none.
+func (f *Framebuffer) GetColorTexture() uint32 {
+	return f.GetColorTextureAt(0)
+}
+
+// GetColorTextureAt returns the ID of the texture attached at
+// GL_COLOR_ATTACHMENT0 + index, or 0 when the framebuffer is multisampled
+// or has no such attachment.
+func (f *Framebuffer) GetColorTextureAt(index int) uint32 {
+	if f.IsMultisampled() || index < 0 || index >= len(f.colorTextureIDs) {
+		return 0
+	}
+	return f.colorTextureIDs[index]
+}
+
+// GetDepthTexture returns the ID of the depth/stencil texture,
+// or 0 when the framebuffer was not created with DepthTexture.
+func (f *Framebuffer) GetDepthTexture() uint32 {
+	if !f.depthTexture {
+		return 0
+	}
+	return f.depthID
+}
+
+// GetColorTextureCount returns the number of color attachments.
+func (f *Framebuffer) GetColorTextureCount() int {
+	if f.IsMultisampled() {
+		return len(f.colorRenderbufferIDs)
+	}
+	return len(f.colorTextureIDs)
+}
+
+// GetSamples returns the number of samples per pixel,
+// 0 when the framebuffer is not multisampled.
+func (f *Framebuffer) GetSamples() int {
+	return f.samples
+}
+
+// IsMultisampled .
+func (f *Framebuffer) IsMultisampled() bool {
+	return f.samples > 1
+}
+
+// ResolveTo blits, and resolves when multisampled, every color
+// attachment and the depth/stencil buffer into dest, which must be
+// single sampled and have the same size, color count and color format.
+// The default framebuffer is bound afterwards.
+func (f *Framebuffer) ResolveTo(dest *Framebuffer) error {
+	if dest.IsMultisampled() {
+		return fmt.Errorf("can not resolve into a multisampled framebuffer")
+	}
+	if f.width != dest.width || f.height != dest.height {
+		return fmt.Errorf("framebuffer size mismatch: %dx%d != %dx%d", f.width, f.height, dest.width, dest.height)
+	}
+	colorCount := f.GetColorTextureCount()
+	if colorCount != dest.GetColorTextureCount() {
+		return fmt.Errorf("framebuffer color attachment count mismatch: %d != %d", colorCount, dest.GetColorTextureCount())
+	}
+	if f.colorFormat != dest.colorFormat {
+		return fmt.Errorf("framebuffer color format mismatch: 0x%x != 0x%x", f.colorFormat, dest.colorFormat)
+	}
+	w, h := int32(f.width), int32(f.height)
+	gl.BindFramebuffer(gl.READ_FRAMEBUFFER, f.id)
+	gl.BindFramebuffer(gl.DRAW_FRAMEBUFFER, dest.id)
+	// a blit writes the read buffer to every draw buffer,
+	// so attachments are resolved one at a time
+	attachments := make([]uint32, colorCount)
+	for i := range attachments {
+		attachments[i] = gl.COLOR_ATTACHMENT0 + uint32(i)
+		gl.ReadBuffer(attachments[i])
+		gl.DrawBuffers(1, &attachments[i])
+		gl.BlitFramebuffer(0, 0, w, h, 0, 0, w, h, gl.COLOR_BUFFER_BIT, gl.NEAREST)
+	}
+	// depth and stencil must be blitted with NEAREST
+	gl.BlitFramebuffer(0, 0, w, h, 0, 0, w, h, gl.DEPTH_BUFFER_BIT|gl.STENCIL_BUFFER_BIT, gl.NEAREST)
+	// read and draw buffers are per framebuffer, restore both
+	gl.BindFramebuffer(gl.FRAMEBUFFER, f.id)
+	setColorBuffers(attachments)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, dest.id)
+	setColorBuffers(attachments)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+	return nil
+}
+
+// GetSize .
+func (f *Framebuffer) GetSize() (int, int) {
+	return f.width, f.height
+}
+
+// Delete frees the attachments and the framebuffer.
+// IDs are zeroed once deleted, which OpenGL ignores, so
+// that deleting twice never deletes names reused since.
+func (f *Framebuffer) Delete() {
+	untrack(f)
+	if f.IsMultisampled() && len(f.colorRenderbufferIDs) > 0 {
+		gl.DeleteRenderbuffers(int32(len(f.colorRenderbufferIDs)), &f.colorRenderbufferIDs[0])
+		zeroIDs(f.colorRenderbufferIDs)
+	} else if len(f.colorTextureIDs) > 0 {
+		gl.DeleteTextures(int32(len(f.colorTextureIDs)), &f.colorTextureIDs[0])
+		zeroIDs(f.colorTextureIDs)
+	}
+	if f.depthTexture {
+		gl.DeleteTextures(1, &f.depthID)
+	} else {
+		gl.DeleteRenderbuffers(1, &f.depthID)
+	}
+	f.depthID = 0
+	gl.DeleteFramebuffers(1, &f.id)
+	f.id = 0
+}
+
+func zeroIDs(ids []uint32) {
+	for i := range ids {
+		ids[i] = 0
+	}
+}
@@ -0,0 +1,14 @@
+//go:build gltest
+// +build gltest
+
+package opengl
+
+import (
+	"testing"
+
+	"github.com/devodev/opengl-experiment/internal/gltest"
+)
+
+func TestMain(m *testing.M) {
+	gltest.Main(m)
+}
@@ -0,0 +1,73 @@
+package opengl
+
+import "github.com/go-gl/gl/v4.6-core/gl"
+
+// GPUTimer measures the GPU time spent on the commands issued
+// between Begin and End using GL_TIME_ELAPSED queries.
+//
+// Reading a query result right after End would stall until the
+// GPU catches up, so two queries are used in turn and ElapsedMS
+// reports the previous measurement: results lag by a frame.
+type GPUTimer struct {
+	queries [2]uint32
+	issued  [2]bool
+	current int
+	elapsed float64
+}
+
+// NewGPUTimer .
+func NewGPUTimer() *GPUTimer {
+	t := &GPUTimer{}
+	gl.GenQueries(int32(len(t.queries)), &t.queries[0])
+	track(t)
+	return t
+}
+
+// Begin starts measuring, timer queries cannot be nested.
+func (t *GPUTimer) Begin() {
+	// the query is about to be reused, its
+	// result must be read even if it stalls
+	if t.issued[t.current] {
+		t.collect(t.current, true)
+	}
+	gl.BeginQuery(gl.TIME_ELAPSED, t.queries[t.current])
+}
+
+// End stops measuring.
+func (t *GPUTimer) End() {
+	gl.EndQuery(gl.TIME_ELAPSED)
+	t.issued[t.current] = true
+	t.current = (t.current + 1) % len(t.queries)
+}
+
+// ElapsedMS returns the last available measurement in
+// milliseconds, from the frame before the last End.
+func (t *GPUTimer) ElapsedMS() float64 {
+	if t.issued[t.current] {
+		t.collect(t.current, false)
+	}
+	return t.elapsed
+}
+
+// collect reads the result of the query, unless it is
+// not available yet and wait is false.
+func (t *GPUTimer) collect(index int, wait bool) {
+	if !wait {
+		var available int32
+		gl.GetQueryObjectiv(t.queries[index], gl.QUERY_RESULT_AVAILABLE, &available)
+		if available == gl.FALSE {
+			return
+		}
+	}
+	var nanoseconds uint64
+	gl.GetQueryObjectui64v(t.queries[index], gl.QUERY_RESULT, &nanoseconds)
+	t.elapsed = float64(nanoseconds) / 1e6
+	t.issued[index] = false
+}
+
+// Delete .
+func (t *GPUTimer) Delete() {
+	untrack(t)
+	gl.DeleteQueries(int32(len(t.queries)), &t.queries[0])
+	t.queries = [2]uint32{}
+}
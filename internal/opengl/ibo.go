@@ -1,38 +1,121 @@
 package opengl
 
 import (
+	"fmt"
 	"unsafe"
 
 	"github.com/go-gl/gl/v4.6-core/gl"
 )
 
-// IBO .
+// IBO holds indices of either gl.UNSIGNED_INT or gl.UNSIGNED_SHORT,
+// the type must be passed to the draw calls using it.
 type IBO struct {
-	id    uint32
-	count int32
+	id        uint32
+	count     int32
+	indexType uint32
+
+	// allocated number of indices
+	capacity int
+	usage    uint32
 }
 
 // NewIBO .
 func NewIBO(count int) *IBO {
 	var iboID uint32
 	gl.GenBuffers(1, &iboID)
-	ibo := &IBO{id: iboID, count: int32(count)}
+	ibo := &IBO{id: iboID, count: int32(count), indexType: gl.UNSIGNED_INT, capacity: count, usage: gl.DYNAMIC_DRAW}
 
 	ibo.Bind()
 	defer ibo.Unbind()
 
-	gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, 4*count, nil, gl.DYNAMIC_DRAW)
+	gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, 4*count, nil, ibo.usage)
 
+	track(ibo)
 	return ibo
 }
 
-// SetData .
+// NewStaticIBO creates an IBO initialized with indices
+// that are not expected to change.
+func NewStaticIBO(indices []uint32) (*IBO, error) {
+	if len(indices) == 0 {
+		return nil, fmt.Errorf("static IBO requires at least one index")
+	}
+	var iboID uint32
+	gl.GenBuffers(1, &iboID)
+	ibo := &IBO{id: iboID, count: int32(len(indices)), indexType: gl.UNSIGNED_INT, capacity: len(indices), usage: gl.STATIC_DRAW}
+
+	ibo.Bind()
+	defer ibo.Unbind()
+
+	gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, 4*len(indices), gl.Ptr(indices), ibo.usage)
+
+	track(ibo)
+	return ibo, nil
+}
+
+// NewStaticIBO16 is like NewStaticIBO using 16 bits indices,
+// halving the memory used by meshes of at most 65536 vertices.
+func NewStaticIBO16(indices []uint16) (*IBO, error) {
+	if len(indices) == 0 {
+		return nil, fmt.Errorf("static IBO requires at least one index")
+	}
+	var iboID uint32
+	gl.GenBuffers(1, &iboID)
+	ibo := &IBO{id: iboID, count: int32(len(indices)), indexType: gl.UNSIGNED_SHORT, capacity: len(indices), usage: gl.STATIC_DRAW}
+
+	ibo.Bind()
+	defer ibo.Unbind()
+
+	gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, 2*len(indices), gl.Ptr(indices), ibo.usage)
+
+	track(ibo)
+	return ibo, nil
+}
+
+// SetData expects 32 bits indices.
 func (v *IBO) SetData(data IBOData) {
+	v.setData(data.GetIBOCount(), data.GetIBOGLPtr(), gl.UNSIGNED_INT)
+}
+
+// SetIndices .
+func (v *IBO) SetIndices(indices []uint32) {
+	if len(indices) == 0 {
+		v.count = 0
+		return
+	}
+	v.setData(int32(len(indices)), gl.Ptr(indices), gl.UNSIGNED_INT)
+}
+
+// SetIndices16 .
+func (v *IBO) SetIndices16(indices []uint16) {
+	if len(indices) == 0 {
+		v.count = 0
+		return
+	}
+	v.setData(int32(len(indices)), gl.Ptr(indices), gl.UNSIGNED_SHORT)
+}
+
+// setData reallocates the buffer when data does not fit or
+// the index type changes, otherwise it is updated in place.
+func (v *IBO) setData(count int32, ptr unsafe.Pointer, indexType uint32) {
 	v.Bind()
 	defer v.Unbind()
 
-	v.count = data.GetIBOCount()
-	gl.BufferSubData(gl.ELEMENT_ARRAY_BUFFER, 0, 4*int(v.count), data.GetIBOGLPtr())
+	v.count = count
+	if needsRealloc(v.capacity, int(v.count)) || indexType != v.indexType {
+		v.capacity = int(v.count)
+		v.indexType = indexType
+		gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, v.indexSize()*v.capacity, ptr, v.usage)
+		return
+	}
+	gl.BufferSubData(gl.ELEMENT_ARRAY_BUFFER, 0, v.indexSize()*int(v.count), ptr)
+}
+
+func (v *IBO) indexSize() int {
+	if v.indexType == gl.UNSIGNED_SHORT {
+		return 2
+	}
+	return 4
 }
 
 // Bind .
@@ -45,11 +128,23 @@ func (v *IBO) Unbind() {
 	gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, 0)
 }
 
+// Delete .
+func (v *IBO) Delete() {
+	untrack(v)
+	gl.DeleteBuffers(1, &v.id)
+	v.id = 0
+}
+
 // GetCount .
 func (v *IBO) GetCount() int32 {
 	return v.count
 }
 
+// GetIndexType returns gl.UNSIGNED_INT or gl.UNSIGNED_SHORT.
+func (v *IBO) GetIndexType() uint32 {
+	return v.indexType
+}
+
 // IBOData .
 type IBOData interface {
 	GetIBOGLPtr() unsafe.Pointer
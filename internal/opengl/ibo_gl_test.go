@@ -0,0 +1,33 @@
+//go:build gltest
+// +build gltest
+
+package opengl
+
+import (
+	"testing"
+
+	"github.com/devodev/opengl-experiment/internal/gltest"
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+func TestIBOIndexType(t *testing.T) {
+	gltest.Context(t)
+
+	ibo, err := NewStaticIBO16([]uint16{0, 1, 2})
+	if err != nil {
+		t.Fatalf("error creating IBO: %s", err)
+	}
+	defer ibo.Delete()
+	if got := ibo.GetIndexType(); got != gl.UNSIGNED_SHORT {
+		t.Errorf("NewStaticIBO16: GetIndexType() = 0x%x, want GL_UNSIGNED_SHORT", got)
+	}
+	// switching the type must be recorded for the draw calls
+	ibo.SetIndices([]uint32{0, 1, 2})
+	if got := ibo.GetIndexType(); got != gl.UNSIGNED_INT {
+		t.Errorf("SetIndices: GetIndexType() = 0x%x, want GL_UNSIGNED_INT", got)
+	}
+	ibo.SetIndices16([]uint16{0, 1, 2})
+	if got := ibo.GetIndexType(); got != gl.UNSIGNED_SHORT {
+		t.Errorf("SetIndices16: GetIndexType() = 0x%x, want GL_UNSIGNED_SHORT", got)
+	}
+}
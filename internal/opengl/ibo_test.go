@@ -0,0 +1,23 @@
+package opengl
+
+import (
+	"testing"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+func TestIBOIndexSize(t *testing.T) {
+	cases := []struct {
+		indexType uint32
+		want      int
+	}{
+		{gl.UNSIGNED_SHORT, 2},
+		{gl.UNSIGNED_INT, 4},
+	}
+	for _, c := range cases {
+		ibo := &IBO{indexType: c.indexType}
+		if got := ibo.indexSize(); got != c.want {
+			t.Errorf("indexSize() of 0x%x = %d, want %d", c.indexType, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package opengl
+
+import "github.com/go-gl/gl/v4.6-core/gl"
+
+// GLInfo describes the current OpenGL context.
+type GLInfo struct {
+	Vendor      string
+	Renderer    string
+	Version     string
+	GLSLVersion string
+	Extensions  []string
+}
+
+// GetGLInfo queries the current context, it must
+// be called after OpenGL has been initialized.
+func GetGLInfo() GLInfo {
+	info := GLInfo{
+		Vendor:      gl.GoStr(gl.GetString(gl.VENDOR)),
+		Renderer:    gl.GoStr(gl.GetString(gl.RENDERER)),
+		Version:     gl.GoStr(gl.GetString(gl.VERSION)),
+		GLSLVersion: gl.GoStr(gl.GetString(gl.SHADING_LANGUAGE_VERSION)),
+	}
+	var count int32
+	gl.GetIntegerv(gl.NUM_EXTENSIONS, &count)
+	info.Extensions = make([]string, 0, count)
+	for i := int32(0); i < count; i++ {
+		info.Extensions = append(info.Extensions, gl.GoStr(gl.GetStringi(gl.EXTENSIONS, uint32(i))))
+	}
+	return info
+}
+
+// HasExtension .
+func (i GLInfo) HasExtension(name string) bool {
+	for _, extension := range i.Extensions {
+		if extension == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GLLimits holds implementation limits of the current context.
+type GLLimits struct {
+	// MaxTextureSize is the largest width or height of a 2D texture
+	MaxTextureSize int32
+	// MaxTextureUnits is the number of texture units
+	// available to all shader stages combined
+	MaxTextureUnits  int32
+	MaxVertexAttribs int32
+	// MaxUniformBlockSize is in bytes
+	MaxUniformBlockSize int32
+	// MaxComputeWorkGroupCount is the largest number of work
+	// groups a compute dispatch can have along X, Y and Z
+	MaxComputeWorkGroupCount [3]int32
+}
+
+// GetGLLimits queries the current context, it must
+// be called after OpenGL has been initialized.
+func GetGLLimits() GLLimits {
+	var limits GLLimits
+	gl.GetIntegerv(gl.MAX_TEXTURE_SIZE, &limits.MaxTextureSize)
+	gl.GetIntegerv(gl.MAX_COMBINED_TEXTURE_IMAGE_UNITS, &limits.MaxTextureUnits)
+	gl.GetIntegerv(gl.MAX_VERTEX_ATTRIBS, &limits.MaxVertexAttribs)
+	gl.GetIntegerv(gl.MAX_UNIFORM_BLOCK_SIZE, &limits.MaxUniformBlockSize)
+	for i := range limits.MaxComputeWorkGroupCount {
+		gl.GetIntegeri_v(gl.MAX_COMPUTE_WORK_GROUP_COUNT, uint32(i), &limits.MaxComputeWorkGroupCount[i])
+	}
+	return limits
+}
@@ -0,0 +1,126 @@
+package opengl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// separableStages maps the shader types supported by
+// separable programs to their name and pipeline stage bit.
+var separableStages = map[uint32]struct {
+	name string
+	bit  uint32
+}{
+	gl.VERTEX_SHADER:   {name: "vertex", bit: gl.VERTEX_SHADER_BIT},
+	gl.GEOMETRY_SHADER: {name: "geometry", bit: gl.GEOMETRY_SHADER_BIT},
+	gl.FRAGMENT_SHADER: {name: "fragment", bit: gl.FRAGMENT_SHADER_BIT},
+}
+
+// NewSeparableShaderProgram compiles a single stage, ex: gl.VERTEX_SHADER,
+// into a program linked with GL_PROGRAM_SEPARABLE, to be combined with
+// other stages in a Pipeline. source must be a null terminated string.
+func NewSeparableShaderProgram(source string, shaderType uint32) (*ShaderProgram, error) {
+	stage, ok := separableStages[shaderType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported separable shader type: 0x%x", shaderType)
+	}
+	shader, err := compileShader(source, shaderType)
+	if err != nil {
+		return nil, fmt.Errorf("could not compile %s shader: %s", stage.name, err)
+	}
+	defer gl.DeleteShader(shader)
+
+	shaderProgramID := gl.CreateProgram()
+	gl.ProgramParameteri(shaderProgramID, gl.PROGRAM_SEPARABLE, gl.TRUE)
+	gl.AttachShader(shaderProgramID, shader)
+	gl.LinkProgram(shaderProgramID)
+	gl.DetachShader(shaderProgramID, shader)
+
+	if err := retrieveProgramLinkError(shaderProgramID); err != nil {
+		gl.DeleteProgram(shaderProgramID)
+		return nil, err
+	}
+	shaderProgram := &ShaderProgram{
+		id:               shaderProgramID,
+		uniformLocations: make(map[string]int32),
+		stageBit:         stage.bit,
+	}
+	track(shaderProgram)
+	return shaderProgram, nil
+}
+
+// IsSeparable .
+func (s *ShaderProgram) IsSeparable() bool {
+	return s.stageBit != 0
+}
+
+// Pipeline combines the stages of separable shader programs,
+// so that one stage can be swapped without relinking the others.
+// A pipeline is only used while no shader program is bound
+// with ShaderProgram.Bind.
+type Pipeline struct {
+	id uint32
+}
+
+// NewPipeline .
+func NewPipeline() *Pipeline {
+	var pipelineID uint32
+	gl.GenProgramPipelines(1, &pipelineID)
+	pipeline := &Pipeline{id: pipelineID}
+	track(pipeline)
+	return pipeline
+}
+
+// UseStage makes the pipeline use program for the stage it was
+// compiled from, replacing the program previously used for it.
+func (p *Pipeline) UseStage(program *ShaderProgram) error {
+	if !program.IsSeparable() {
+		return fmt.Errorf("shader program %d is not separable", program.id)
+	}
+	gl.UseProgramStages(p.id, program.stageBit, program.id)
+	return nil
+}
+
+// SetActiveProgram directs the ShaderProgram.SetUniform* calls
+// to program while the pipeline is bound.
+func (p *Pipeline) SetActiveProgram(program *ShaderProgram) {
+	gl.ActiveShaderProgram(p.id, program.id)
+}
+
+// Validate checks that the stages can be used together,
+// ex: that the outputs of a stage match the inputs of the next.
+func (p *Pipeline) Validate() error {
+	gl.ValidateProgramPipeline(p.id)
+
+	var status int32
+	gl.GetProgramPipelineiv(p.id, gl.VALIDATE_STATUS, &status)
+	if status == gl.FALSE {
+		var logLength int32
+		gl.GetProgramPipelineiv(p.id, gl.INFO_LOG_LENGTH, &logLength)
+
+		log := strings.Repeat("\x00", int(logLength+1))
+		gl.GetProgramPipelineInfoLog(p.id, logLength, nil, gl.Str(log))
+
+		return fmt.Errorf("invalid program pipeline %d: %v", p.id, log)
+	}
+	return nil
+}
+
+// Bind .
+func (p *Pipeline) Bind() {
+	gl.BindProgramPipeline(p.id)
+}
+
+// Unbind .
+func (p *Pipeline) Unbind() {
+	gl.BindProgramPipeline(0)
+}
+
+// Delete does not delete the shader programs used by the pipeline.
+func (p *Pipeline) Delete() {
+	untrack(p)
+	gl.DeleteProgramPipelines(1, &p.id)
+	p.id = 0
+}
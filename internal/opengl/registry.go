@@ -0,0 +1,54 @@
+package opengl
+
+// deletable is an OpenGL object owning GPU memory.
+type deletable interface {
+	Delete()
+}
+
+// live holds the objects created and not yet deleted,
+// so that they can all be released at exit by DeleteAll.
+// Like every OpenGL call, it must only be used from the main thread.
+var live = make(map[deletable]struct{})
+
+func track(obj deletable) {
+	live[obj] = struct{}{}
+}
+
+func untrack(obj deletable) {
+	delete(live, obj)
+}
+
+// fileShaderPrograms holds the programs created from files and not
+// yet deleted, in creation order, so that every ShaderWatcher
+// watches them without having to be told about each one.
+var fileShaderPrograms []*ShaderProgram
+
+func registerFileShaderProgram(s *ShaderProgram) {
+	fileShaderPrograms = append(fileShaderPrograms, s)
+}
+
+func unregisterFileShaderProgram(s *ShaderProgram) {
+	for i, program := range fileShaderPrograms {
+		if program == s {
+			fileShaderPrograms = append(fileShaderPrograms[:i], fileShaderPrograms[i+1:]...)
+			return
+		}
+	}
+}
+
+// LiveObjects returns the number of objects
+// created and not yet deleted.
+func LiveObjects() int {
+	return len(live)
+}
+
+// DeleteAll deletes every object not yet deleted, it must
+// be called before the OpenGL context is destroyed.
+// It returns the number of objects deleted.
+func DeleteAll() int {
+	count := len(live)
+	for obj := range live {
+		obj.Delete()
+	}
+	return count
+}
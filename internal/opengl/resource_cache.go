@@ -0,0 +1,127 @@
+package opengl
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// ResourceCache loads textures and shader programs from files once,
+// handing out the same object on repeated loads. Objects are
+// reference counted and deleted when the last reference is released.
+type ResourceCache struct {
+	textures       map[string]*cachedTexture
+	shaderPrograms map[string]*cachedShaderProgram
+}
+
+type cachedTexture struct {
+	texture    *Texture
+	references int
+}
+
+type cachedShaderProgram struct {
+	program    *ShaderProgram
+	references int
+}
+
+// ResourceCacheStats .
+type ResourceCacheStats struct {
+	Textures       int
+	ShaderPrograms int
+	// References is the total number of unreleased loads
+	References int
+}
+
+// NewResourceCache .
+func NewResourceCache() *ResourceCache {
+	return &ResourceCache{
+		textures:       make(map[string]*cachedTexture),
+		shaderPrograms: make(map[string]*cachedShaderProgram),
+	}
+}
+
+// LoadTexture returns the texture already loaded from path, or
+// creates it with NewTexture. A texture is bound to a single index,
+// loading it again with a different one is an error.
+func (c *ResourceCache) LoadTexture(path string, index int) (*Texture, error) {
+	key := filepath.Clean(path)
+	if cached, ok := c.textures[key]; ok {
+		if cached.texture.GetIndex() != index {
+			return nil, fmt.Errorf("texture %q already loaded with index %d: %d", path, cached.texture.GetIndex(), index)
+		}
+		cached.references++
+		return cached.texture, nil
+	}
+	texture, err := NewTexture(path, index)
+	if err != nil {
+		return nil, err
+	}
+	c.textures[key] = &cachedTexture{texture: texture, references: 1}
+	return texture, nil
+}
+
+// ReleaseTexture drops a reference to the texture loaded from path,
+// deleting it when no references are left.
+func (c *ResourceCache) ReleaseTexture(path string) error {
+	key := filepath.Clean(path)
+	cached, ok := c.textures[key]
+	if !ok {
+		return fmt.Errorf("texture not loaded: %q", path)
+	}
+	cached.references--
+	if cached.references == 0 {
+		cached.texture.Delete()
+		delete(c.textures, key)
+	}
+	return nil
+}
+
+// LoadShaderProgram returns the shader program already created
+// from the same files, or creates it with NewShaderProgramFromFiles.
+func (c *ResourceCache) LoadShaderProgram(vertexShaderPath, fragmentShaderPath string) (*ShaderProgram, error) {
+	key := shaderProgramKey(vertexShaderPath, fragmentShaderPath)
+	if cached, ok := c.shaderPrograms[key]; ok {
+		cached.references++
+		return cached.program, nil
+	}
+	program, err := NewShaderProgramFromFiles(vertexShaderPath, fragmentShaderPath)
+	if err != nil {
+		return nil, err
+	}
+	c.shaderPrograms[key] = &cachedShaderProgram{program: program, references: 1}
+	return program, nil
+}
+
+// ReleaseShaderProgram drops a reference to the shader program
+// created from the files, deleting it when no references are left.
+func (c *ResourceCache) ReleaseShaderProgram(vertexShaderPath, fragmentShaderPath string) error {
+	key := shaderProgramKey(vertexShaderPath, fragmentShaderPath)
+	cached, ok := c.shaderPrograms[key]
+	if !ok {
+		return fmt.Errorf("shader program not loaded: %q, %q", vertexShaderPath, fragmentShaderPath)
+	}
+	cached.references--
+	if cached.references == 0 {
+		cached.program.Delete()
+		delete(c.shaderPrograms, key)
+	}
+	return nil
+}
+
+// Stats .
+func (c *ResourceCache) Stats() ResourceCacheStats {
+	stats := ResourceCacheStats{
+		Textures:       len(c.textures),
+		ShaderPrograms: len(c.shaderPrograms),
+	}
+	for _, cached := range c.textures {
+		stats.References += cached.references
+	}
+	for _, cached := range c.shaderPrograms {
+		stats.References += cached.references
+	}
+	return stats
+}
+
+func shaderProgramKey(vertexShaderPath, fragmentShaderPath string) string {
+	return filepath.Clean(vertexShaderPath) + "\x00" + filepath.Clean(fragmentShaderPath)
+}
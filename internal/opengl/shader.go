@@ -2,6 +2,8 @@ package opengl
 
 import (
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	"github.com/go-gl/gl/v4.6-core/gl"
@@ -9,44 +11,167 @@ import (
 
 // ShaderProgram .
 type ShaderProgram struct {
-	id               uint32
+	id uint32
+	// locations are only valid for the program they were
+	// queried from, reset the cache whenever id changes
 	uniformLocations map[string]int32
+	// set when created from files, used to reload the program
+	files []shaderStage
+	// files included by the stage files, updated on reload
+	includePaths []string
+	// set for separable programs, the pipeline stage bit
+	// of the single stage they were compiled from
+	stageBit uint32
+}
+
+// shaderStage is a single shader source to be
+// compiled and linked into a program
+type shaderStage struct {
+	name       string
+	shaderType uint32
+	source     string
+	path       string
 }
 
 // NewShaderProgram requires that both vertex and fragment shader
 // sources be null terminated strings.
 func NewShaderProgram(vertexShaderSource, fragmentShaderSource string) (*ShaderProgram, error) {
-	// compile shaders
-	vertexShader, err := compileShader(vertexShaderSource, gl.VERTEX_SHADER)
+	return newShaderProgram(
+		shaderStage{name: "vertex", shaderType: gl.VERTEX_SHADER, source: vertexShaderSource},
+		shaderStage{name: "fragment", shaderType: gl.FRAGMENT_SHADER, source: fragmentShaderSource},
+	)
+}
+
+// NewShaderProgramWithGeometry requires that all shader
+// sources be null terminated strings.
+func NewShaderProgramWithGeometry(vertexShaderSource, geometryShaderSource, fragmentShaderSource string) (*ShaderProgram, error) {
+	return newShaderProgram(
+		shaderStage{name: "vertex", shaderType: gl.VERTEX_SHADER, source: vertexShaderSource},
+		shaderStage{name: "geometry", shaderType: gl.GEOMETRY_SHADER, source: geometryShaderSource},
+		shaderStage{name: "fragment", shaderType: gl.FRAGMENT_SHADER, source: fragmentShaderSource},
+	)
+}
+
+func newShaderProgram(stages ...shaderStage) (*ShaderProgram, error) {
+	shaderProgramID, err := linkProgram(stages...)
 	if err != nil {
-		return nil, fmt.Errorf("could not compile vertex shader: %s", err)
+		return nil, err
 	}
-	fragmentShader, err := compileShader(fragmentShaderSource, gl.FRAGMENT_SHADER)
-	if err != nil {
-		return nil, fmt.Errorf("could not compile fragment shader: %s", err)
+	shaderProgram := &ShaderProgram{
+		id:               shaderProgramID,
+		uniformLocations: make(map[string]int32),
+	}
+	track(shaderProgram)
+	return shaderProgram, nil
+}
+
+// linkProgram compiles the shader stages and links them
+// into a new program, returning its ID.
+func linkProgram(stages ...shaderStage) (uint32, error) {
+	// compile shaders
+	shaders := make([]uint32, 0, len(stages))
+	defer func() {
+		for _, shader := range shaders {
+			gl.DeleteShader(shader)
+		}
+	}()
+	for _, stage := range stages {
+		shader, err := compileShader(stage.source, stage.shaderType)
+		if err != nil {
+			if stage.path != "" {
+				return 0, fmt.Errorf("could not compile %s shader %q: %s", stage.name, stage.path, err)
+			}
+			return 0, fmt.Errorf("could not compile %s shader: %s", stage.name, err)
+		}
+		shaders = append(shaders, shader)
 	}
 
 	// create shader program and link the shaders previously compiled
 	shaderProgramID := gl.CreateProgram()
-	gl.AttachShader(shaderProgramID, vertexShader)
-	gl.AttachShader(shaderProgramID, fragmentShader)
+	for _, shader := range shaders {
+		gl.AttachShader(shaderProgramID, shader)
+	}
 	gl.LinkProgram(shaderProgramID)
 	gl.ValidateProgram(shaderProgramID)
 
-	defer func() {
-		gl.DetachShader(shaderProgramID, vertexShader)
-		gl.DetachShader(shaderProgramID, fragmentShader)
-		gl.DeleteShader(vertexShader)
-		gl.DeleteShader(fragmentShader)
-	}()
+	for _, shader := range shaders {
+		gl.DetachShader(shaderProgramID, shader)
+	}
 
 	if err := retrieveProgramLinkError(shaderProgramID); err != nil {
+		gl.DeleteProgram(shaderProgramID)
+		return 0, err
+	}
+	return shaderProgramID, nil
+}
+
+// NewShaderProgramFromFiles reads the vertex and fragment shader
+// sources from files. Relative paths are resolved against the
+// current working directory. The program is watched by
+// every ShaderWatcher until deleted.
+func NewShaderProgramFromFiles(vertexShaderPath, fragmentShaderPath string) (*ShaderProgram, error) {
+	files := []shaderStage{
+		{name: "vertex", shaderType: gl.VERTEX_SHADER, path: vertexShaderPath},
+		{name: "fragment", shaderType: gl.FRAGMENT_SHADER, path: fragmentShaderPath},
+	}
+	stages, includePaths, err := readShaderStages(files)
+	if err != nil {
 		return nil, err
 	}
+	shaderProgram, err := newShaderProgram(stages...)
+	if err != nil {
+		return nil, err
+	}
+	shaderProgram.files = files
+	shaderProgram.includePaths = includePaths
+	registerFileShaderProgram(shaderProgram)
+	return shaderProgram, nil
+}
 
-	shaderProgram := &ShaderProgram{id: shaderProgramID}
+// Reload recompiles a program created from files. The program
+// is left untouched if an error occurs, otherwise uniforms
+// need to be set again.
+func (s *ShaderProgram) Reload() error {
+	if len(s.files) == 0 {
+		return fmt.Errorf("shader program %d was not created from files", s.id)
+	}
+	stages, includePaths, err := readShaderStages(s.files)
+	if err != nil {
+		return err
+	}
+	shaderProgramID, err := linkProgram(stages...)
+	if err != nil {
+		return err
+	}
+	gl.DeleteProgram(s.id)
+	s.id = shaderProgramID
+	s.includePaths = includePaths
+	s.uniformLocations = make(map[string]int32)
+	return nil
+}
 
-	return shaderProgram, nil
+// GetSourcePaths returns the paths of the files the program
+// was created from, if any.
+func (s *ShaderProgram) GetSourcePaths() []string {
+	paths := make([]string, 0, len(s.files))
+	for _, f := range s.files {
+		paths = append(paths, f.path)
+	}
+	return paths
+}
+
+// GetIncludePaths returns the absolute paths of the files included
+// by the source files of the program, as of its last (re)load.
+func (s *ShaderProgram) GetIncludePaths() []string {
+	return s.includePaths
+}
+
+// Delete .
+func (s *ShaderProgram) Delete() {
+	untrack(s)
+	unregisterFileShaderProgram(s)
+	gl.DeleteProgram(s.id)
+	s.id = 0
 }
 
 // Bind .
@@ -60,11 +185,28 @@ func (s *ShaderProgram) Unbind() {
 }
 
 func (s *ShaderProgram) getUniformLocation(name string) int32 {
+	if location, ok := s.uniformLocations[name]; ok {
+		return location
+	}
+	location := gl.GetUniformLocation(s.id, gl.Str(name+"\x00"))
+	// uniform does not exist or was optimized out by the compiler,
+	// report it once so that typos are discoverable
+	if location == -1 {
+		fmt.Printf("[OpenGL WARNING] uniform not found in shader program %d: %q\n", s.id, name)
+	}
+	s.uniformLocations[name] = location
+	return location
+}
+
+// HasUniform reports whether the program has an active
+// uniform with that name, without warning when it does not.
+func (s *ShaderProgram) HasUniform(name string) bool {
 	location, ok := s.uniformLocations[name]
 	if !ok {
 		location = gl.GetUniformLocation(s.id, gl.Str(name+"\x00"))
+		s.uniformLocations[name] = location
 	}
-	return location
+	return location != -1
 }
 
 // SetUniform1f .
@@ -72,6 +214,11 @@ func (s *ShaderProgram) SetUniform1f(name string, v0 float32) {
 	gl.Uniform1f(s.getUniformLocation(name), v0)
 }
 
+// SetUniform1fv .
+func (s *ShaderProgram) SetUniform1fv(name string, count int32, value *float32) {
+	gl.Uniform1fv(s.getUniformLocation(name), count, value)
+}
+
 // SetUniform1i .
 func (s *ShaderProgram) SetUniform1i(name string, v0 int32) {
 	gl.Uniform1i(s.getUniformLocation(name), v0)
@@ -82,16 +229,80 @@ func (s *ShaderProgram) SetUniform1iv(name string, count int32, value *int32) {
 	gl.Uniform1iv(s.getUniformLocation(name), count, value)
 }
 
+// SetUniform2f .
+func (s *ShaderProgram) SetUniform2f(name string, v0, v1 float32) {
+	gl.Uniform2f(s.getUniformLocation(name), v0, v1)
+}
+
+// SetUniform3f .
+func (s *ShaderProgram) SetUniform3f(name string, v0, v1, v2 float32) {
+	gl.Uniform3f(s.getUniformLocation(name), v0, v1, v2)
+}
+
 // SetUniform4f .
 func (s *ShaderProgram) SetUniform4f(name string, v0, v1, v2, v3 float32) {
 	gl.Uniform4f(s.getUniformLocation(name), v0, v1, v2, v3)
 }
 
+// SetUniformMatrix3fv .
+func (s *ShaderProgram) SetUniformMatrix3fv(name string, count int32, transpose bool, value *float32) {
+	gl.UniformMatrix3fv(s.getUniformLocation(name), count, transpose, value)
+}
+
 // SetUniformMatrix4fv .
 func (s *ShaderProgram) SetUniformMatrix4fv(name string, count int32, transpose bool, value *float32) {
 	gl.UniformMatrix4fv(s.getUniformLocation(name), count, transpose, value)
 }
 
+// SetUniformBlockBinding links the uniform block name
+// to the binding point of a UBO.
+func (s *ShaderProgram) SetUniformBlockBinding(name string, binding uint32) error {
+	index := gl.GetUniformBlockIndex(s.id, gl.Str(name+"\x00"))
+	if index == gl.INVALID_INDEX {
+		return fmt.Errorf("uniform block not found in shader program %d: %q", s.id, name)
+	}
+	gl.UniformBlockBinding(s.id, index, binding)
+	return nil
+}
+
+// readShaderStages also returns the files included by any
+// of the stages, each listed once.
+func readShaderStages(files []shaderStage) ([]shaderStage, []string, error) {
+	stages := make([]shaderStage, 0, len(files))
+	var includePaths []string
+	seen := make(map[string]bool)
+	for _, f := range files {
+		source, includes, err := readShaderFile(f.path)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, include := range includes {
+			if !seen[include] {
+				seen[include] = true
+				includePaths = append(includePaths, include)
+			}
+		}
+		f.source = source
+		stages = append(stages, f)
+	}
+	return stages, includePaths, nil
+}
+
+// readShaderFile returns the content of the file, with its
+// include directives expanded, as a null terminated string,
+// along with the paths of the included files.
+func readShaderFile(path string) (string, []string, error) {
+	source, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("error reading shader file %q: %s", path, err)
+	}
+	preprocessed, includes, err := PreprocessShader(string(source), filepath.Dir(path))
+	if err != nil {
+		return "", nil, fmt.Errorf("error preprocessing shader file %q: %s", path, err)
+	}
+	return preprocessed + "\x00", includes, nil
+}
+
 func compileShader(source string, shaderType uint32) (uint32, error) {
 	shaderProgramID := gl.CreateShader(shaderType)
 
@@ -101,6 +312,7 @@ func compileShader(source string, shaderType uint32) (uint32, error) {
 
 	gl.CompileShader(shaderProgramID)
 	if err := retrieveShaderCompileError(shaderProgramID); err != nil {
+		gl.DeleteShader(shaderProgramID)
 		return 0, err
 	}
 	return shaderProgramID, nil
@@ -0,0 +1,60 @@
+//go:build gltest
+// +build gltest
+
+package opengl
+
+import (
+	"testing"
+
+	"github.com/devodev/opengl-experiment/internal/gltest"
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+const (
+	uniformVertexShader = `
+#version 460 core
+layout (location = 0) in vec3 position;
+
+uniform mat4 model;
+
+void main() {
+    gl_Position = model * vec4(position, 1.0);
+}
+` + "\x00"
+
+	uniformFragmentShader = `
+#version 460 core
+layout (location = 0) out vec4 fragColor;
+
+void main() {
+    fragColor = vec4(1.0);
+}
+` + "\x00"
+)
+
+func newUniformShaderProgram(b *testing.B) *ShaderProgram {
+	b.Helper()
+	program, err := NewShaderProgram(uniformVertexShader, uniformFragmentShader)
+	if err != nil {
+		b.Fatalf("error creating shader program: %s", err)
+	}
+	return program
+}
+
+func BenchmarkUniformLocation(b *testing.B) {
+	gltest.Context(b)
+
+	program := newUniformShaderProgram(b)
+	defer program.Delete()
+
+	b.Run("cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			program.getUniformLocation("model")
+		}
+	})
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			gl.GetUniformLocation(program.id, gl.Str("model\x00"))
+		}
+	})
+}
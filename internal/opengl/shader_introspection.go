@@ -0,0 +1,93 @@
+package opengl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+var glslTypeNames = map[uint32]string{
+	gl.FLOAT:             "float",
+	gl.FLOAT_VEC2:        "vec2",
+	gl.FLOAT_VEC3:        "vec3",
+	gl.FLOAT_VEC4:        "vec4",
+	gl.DOUBLE:            "double",
+	gl.INT:               "int",
+	gl.INT_VEC2:          "ivec2",
+	gl.INT_VEC3:          "ivec3",
+	gl.INT_VEC4:          "ivec4",
+	gl.UNSIGNED_INT:      "uint",
+	gl.UNSIGNED_INT_VEC2: "uvec2",
+	gl.UNSIGNED_INT_VEC3: "uvec3",
+	gl.UNSIGNED_INT_VEC4: "uvec4",
+	gl.BOOL:              "bool",
+	gl.FLOAT_MAT2:        "mat2",
+	gl.FLOAT_MAT3:        "mat3",
+	gl.FLOAT_MAT4:        "mat4",
+	gl.SAMPLER_2D:        "sampler2D",
+	gl.SAMPLER_3D:        "sampler3D",
+	gl.SAMPLER_CUBE:      "samplerCube",
+	gl.SAMPLER_2D_SHADOW: "sampler2DShadow",
+	gl.IMAGE_2D:          "image2D",
+}
+
+// ActiveVariable is an attribute or uniform
+// used by a linked shader program.
+type ActiveVariable struct {
+	Name     string
+	Type     uint32
+	TypeName string
+	// Size is the number of elements of arrays, 1 otherwise
+	Size     int32
+	Location int32
+}
+
+// GetActiveAttributes .
+func (s *ShaderProgram) GetActiveAttributes() []ActiveVariable {
+	return s.getActiveVariables(gl.ACTIVE_ATTRIBUTES, gl.ACTIVE_ATTRIBUTE_MAX_LENGTH, gl.GetActiveAttrib, gl.GetAttribLocation)
+}
+
+// GetActiveUniforms .
+func (s *ShaderProgram) GetActiveUniforms() []ActiveVariable {
+	return s.getActiveVariables(gl.ACTIVE_UNIFORMS, gl.ACTIVE_UNIFORM_MAX_LENGTH, gl.GetActiveUniform, gl.GetUniformLocation)
+}
+
+func (s *ShaderProgram) getActiveVariables(
+	countParam uint32,
+	maxLengthParam uint32,
+	getActive func(uint32, uint32, int32, *int32, *int32, *uint32, *uint8),
+	getLocation func(uint32, *uint8) int32) []ActiveVariable {
+
+	var count, maxLength int32
+	gl.GetProgramiv(s.id, countParam, &count)
+	gl.GetProgramiv(s.id, maxLengthParam, &maxLength)
+
+	variables := make([]ActiveVariable, 0, count)
+	for i := int32(0); i < count; i++ {
+		var length, size int32
+		var glType uint32
+		name := strings.Repeat("\x00", int(maxLength+1))
+		getActive(s.id, uint32(i), maxLength, &length, &size, &glType, gl.Str(name))
+		name = name[:length]
+
+		variables = append(variables, ActiveVariable{
+			Name:     name,
+			Type:     glType,
+			TypeName: glslTypeName(glType),
+			Size:     size,
+			Location: getLocation(s.id, gl.Str(name+"\x00")),
+		})
+	}
+	return variables
+}
+
+func glslTypeName(glType uint32) string {
+	if name, ok := glslTypeNames[glType]; ok {
+		return name
+	}
+	if names, ok := GlEnums[glType]; ok {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("0x%x", glType)
+}
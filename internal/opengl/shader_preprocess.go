@@ -0,0 +1,96 @@
+package opengl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// PreprocessShader expands `#include "file"` directives found in
+// source, reading included files relative to the directory of the
+// file including them, baseDir for source itself.
+//
+// `#line` directives are inserted around included content so that
+// compile errors report the line in the right file. Each file is
+// given a source string number in order of first inclusion,
+// 0 being source itself.
+//
+// It also returns the absolute paths of the included files,
+// in the same order, ex: to reload the shader when they change.
+func PreprocessShader(source, baseDir string) (string, []string, error) {
+	p := &shaderPreprocessor{fileNumbers: make(map[string]int)}
+	var out strings.Builder
+	if err := p.expand(&out, source, baseDir, "", 0); err != nil {
+		return "", nil, err
+	}
+	return out.String(), p.includes, nil
+}
+
+type shaderPreprocessor struct {
+	// absolute paths of the files being expanded,
+	// used to detect include cycles
+	stack       []string
+	fileNumbers map[string]int
+	// absolute paths in order of first inclusion
+	includes []string
+}
+
+func (p *shaderPreprocessor) expand(out *strings.Builder, source, baseDir, name string, fileNumber int) error {
+	lines := strings.Split(strings.TrimSuffix(source, "\n"), "\n")
+	for idx, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "#include") {
+			out.WriteString(line)
+			out.WriteString("\n")
+			continue
+		}
+		includePath, err := parseIncludeDirective(trimmed)
+		if err != nil {
+			return fmt.Errorf("%s:%d: %s", displayName(name), idx+1, err)
+		}
+		path, err := filepath.Abs(filepath.Join(baseDir, includePath))
+		if err != nil {
+			return fmt.Errorf("%s:%d: %s", displayName(name), idx+1, err)
+		}
+		for _, included := range p.stack {
+			if included == path {
+				return fmt.Errorf("%s:%d: include cycle detected: %s", displayName(name), idx+1, strings.Join(append(p.stack, path), " -> "))
+			}
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("%s:%d: error reading included file %q: %s", displayName(name), idx+1, includePath, err)
+		}
+		number, ok := p.fileNumbers[path]
+		if !ok {
+			number = len(p.fileNumbers) + 1
+			p.fileNumbers[path] = number
+			p.includes = append(p.includes, path)
+		}
+
+		fmt.Fprintf(out, "#line 1 %d\n", number)
+		p.stack = append(p.stack, path)
+		if err := p.expand(out, string(content), filepath.Dir(path), path, number); err != nil {
+			return err
+		}
+		p.stack = p.stack[:len(p.stack)-1]
+		fmt.Fprintf(out, "#line %d %d\n", idx+2, fileNumber)
+	}
+	return nil
+}
+
+func parseIncludeDirective(line string) (string, error) {
+	arg := strings.TrimSpace(strings.TrimPrefix(line, "#include"))
+	if len(arg) < 2 || arg[0] != '"' || arg[len(arg)-1] != '"' {
+		return "", fmt.Errorf("malformed include directive: %s", line)
+	}
+	return arg[1 : len(arg)-1], nil
+}
+
+func displayName(name string) string {
+	if name == "" {
+		return "<source>"
+	}
+	return name
+}
@@ -0,0 +1,137 @@
+package opengl
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// ShaderWatcher reloads the shader programs created from files with
+// NewShaderProgramFromFiles whenever one of their source files is
+// modified, or on demand with ReloadAll. Programs are watched from
+// their creation until they are deleted.
+type ShaderWatcher struct {
+	programs []*watchedShaderProgram
+}
+
+type watchedShaderProgram struct {
+	program  *ShaderProgram
+	modTimes map[string]time.Time
+}
+
+// NewShaderWatcher .
+func NewShaderWatcher() *ShaderWatcher {
+	return &ShaderWatcher{}
+}
+
+// Add starts watching the source files of the program right away,
+// rather than on the next Check. It is not required, all programs
+// created from files are watched, it only fails for the others.
+func (w *ShaderWatcher) Add(program *ShaderProgram) error {
+	if len(program.GetSourcePaths()) == 0 {
+		return fmt.Errorf("shader program %d was not created from files", program.id)
+	}
+	w.sync()
+	return nil
+}
+
+// sync starts watching the programs created from files since the
+// last call and stops watching the ones which were deleted.
+func (w *ShaderWatcher) sync() {
+	existing := make(map[*ShaderProgram]*watchedShaderProgram, len(w.programs))
+	for _, watched := range w.programs {
+		existing[watched.program] = watched
+	}
+	programs := make([]*watchedShaderProgram, 0, len(fileShaderPrograms))
+	for _, program := range fileShaderPrograms {
+		watched, ok := existing[program]
+		if !ok {
+			watched = &watchedShaderProgram{
+				program:  program,
+				modTimes: make(map[string]time.Time),
+			}
+			watched.updatePaths()
+		}
+		programs = append(programs, watched)
+	}
+	w.programs = programs
+}
+
+// Check polls the modification time of the watched files and
+// reloads the programs whose sources changed. It is meant to be
+// called once per frame. A program failing to reload is kept
+// as is and the error is printed, it is retried on the next change.
+//
+// It returns the programs that were reloaded, so that the caller
+// can set their uniforms again.
+func (w *ShaderWatcher) Check() []*ShaderProgram {
+	w.sync()
+	var reloaded []*ShaderProgram
+	for _, watched := range w.programs {
+		changed := false
+		for path, last := range watched.modTimes {
+			current := modTime(path)
+			if !current.Equal(last) {
+				watched.modTimes[path] = current
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+		if err := watched.program.Reload(); err != nil {
+			fmt.Printf("[OpenGL WARNING] error reloading shader program: %s\n", err)
+			continue
+		}
+		watched.updatePaths()
+		reloaded = append(reloaded, watched.program)
+	}
+	return reloaded
+}
+
+// ReloadAll reloads every watched program, whether its sources changed
+// or not, ex: from a hotkey. Programs failing to reload are kept as
+// is and the errors are printed. It returns the programs that
+// were reloaded, like Check.
+func (w *ShaderWatcher) ReloadAll() []*ShaderProgram {
+	w.sync()
+	var reloaded []*ShaderProgram
+	for _, watched := range w.programs {
+		// a manual reload supersedes the pending changes
+		for path := range watched.modTimes {
+			watched.modTimes[path] = modTime(path)
+		}
+		if err := watched.program.Reload(); err != nil {
+			fmt.Printf("[OpenGL WARNING] error reloading shader program: %s\n", err)
+			continue
+		}
+		watched.updatePaths()
+		reloaded = append(reloaded, watched.program)
+	}
+	return reloaded
+}
+
+// updatePaths syncs the watched files with the source and include
+// paths of the program, which change when includes are edited.
+func (w *watchedShaderProgram) updatePaths() {
+	paths := append(w.program.GetSourcePaths(), w.program.GetIncludePaths()...)
+	modTimes := make(map[string]time.Time, len(paths))
+	for _, path := range paths {
+		if last, ok := w.modTimes[path]; ok {
+			modTimes[path] = last
+			continue
+		}
+		modTimes[path] = modTime(path)
+	}
+	w.modTimes = modTimes
+}
+
+// modTime returns the zero time if the file can not be read,
+// ex: while an editor is replacing it.
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,91 @@
+package opengl
+
+import (
+	"fmt"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// SSBO is a shader storage buffer object bound
+// to an indexed binding point, typically used
+// to exchange data with compute shaders.
+type SSBO struct {
+	id      uint32
+	binding uint32
+	size    int
+}
+
+// NewSSBO allocates a buffer holding data and binds it
+// to binding, matching `layout(std430, binding = N)`.
+func NewSSBO(data []float32, binding uint32) (*SSBO, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("SSBO requires at least one element")
+	}
+	var ssboID uint32
+	gl.GenBuffers(1, &ssboID)
+	ssbo := &SSBO{id: ssboID, binding: binding, size: 4 * len(data)}
+
+	ssbo.Bind()
+	defer ssbo.Unbind()
+
+	gl.BufferData(gl.SHADER_STORAGE_BUFFER, ssbo.size, gl.Ptr(data), gl.DYNAMIC_COPY)
+	gl.BindBufferBase(gl.SHADER_STORAGE_BUFFER, ssbo.binding, ssbo.id)
+
+	track(ssbo)
+	return ssbo, nil
+}
+
+// SetData replaces the content of the buffer,
+// data must not be larger than the buffer.
+func (s *SSBO) SetData(data []float32) error {
+	if 4*len(data) > s.size {
+		return fmt.Errorf("SSBO data too large: %d > %d bytes", 4*len(data), s.size)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	s.Bind()
+	defer s.Unbind()
+
+	gl.BufferSubData(gl.SHADER_STORAGE_BUFFER, 0, 4*len(data), gl.Ptr(data))
+	return nil
+}
+
+// Read copies the start of the buffer into out.
+// Make sure writes are visible before reading,
+// see ShaderProgram.Dispatch.
+func (s *SSBO) Read(out []float32) error {
+	if 4*len(out) > s.size {
+		return fmt.Errorf("SSBO read too large: %d > %d bytes", 4*len(out), s.size)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	s.Bind()
+	defer s.Unbind()
+
+	gl.GetBufferSubData(gl.SHADER_STORAGE_BUFFER, 0, 4*len(out), gl.Ptr(out))
+	return nil
+}
+
+// GetBinding .
+func (s *SSBO) GetBinding() uint32 {
+	return s.binding
+}
+
+// Bind .
+func (s *SSBO) Bind() {
+	gl.BindBuffer(gl.SHADER_STORAGE_BUFFER, s.id)
+}
+
+// Unbind .
+func (s *SSBO) Unbind() {
+	gl.BindBuffer(gl.SHADER_STORAGE_BUFFER, 0)
+}
+
+// Delete .
+func (s *SSBO) Delete() {
+	untrack(s)
+	gl.DeleteBuffers(1, &s.id)
+	s.id = 0
+}
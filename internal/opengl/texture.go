@@ -7,6 +7,7 @@ import (
 
 	// need to initialize each image type
 	// that could be used in NewTexture
+	_ "image/jpeg"
 	_ "image/png"
 
 	"github.com/disintegration/imaging"
@@ -19,17 +20,110 @@ type Texture struct {
 	id          uint32
 	index       int
 	textureUnit uint32
+	width       int
+	height      int
 }
 
-// NewTexture .
+// TextureOptions zero values select the defaults:
+// LINEAR filtering, or LINEAR_MIPMAP_LINEAR minification
+// when GenerateMipmaps is set, and CLAMP_TO_EDGE wrapping.
+type TextureOptions struct {
+	GenerateMipmaps bool
+	MinFilter       int32
+	MagFilter       int32
+	WrapMode        int32
+	// Anisotropy sharpens textures viewed at grazing angles when
+	// greater than 1, it is clamped to the maximum supported.
+	Anisotropy float32
+	// SRGB stores the texture as GL_SRGB8_ALPHA8, decoded to
+	// linear values when sampled. Use it for textures holding
+	// colors, ex: diffuse maps, not for data such as normal maps.
+	SRGB bool
+}
+
+func (o TextureOptions) validate() (TextureOptions, error) {
+	if o.MinFilter == 0 {
+		o.MinFilter = gl.LINEAR
+		if o.GenerateMipmaps {
+			o.MinFilter = gl.LINEAR_MIPMAP_LINEAR
+		}
+	}
+	if o.MagFilter == 0 {
+		o.MagFilter = gl.LINEAR
+	}
+	if o.WrapMode == 0 {
+		o.WrapMode = gl.CLAMP_TO_EDGE
+	}
+	switch o.MinFilter {
+	case gl.NEAREST, gl.LINEAR:
+	case gl.NEAREST_MIPMAP_NEAREST, gl.LINEAR_MIPMAP_NEAREST, gl.NEAREST_MIPMAP_LINEAR, gl.LINEAR_MIPMAP_LINEAR:
+		if !o.GenerateMipmaps {
+			return o, fmt.Errorf("texture min filter 0x%x requires mipmaps", o.MinFilter)
+		}
+	default:
+		return o, fmt.Errorf("invalid texture min filter: 0x%x", o.MinFilter)
+	}
+	switch o.MagFilter {
+	case gl.NEAREST, gl.LINEAR:
+	default:
+		return o, fmt.Errorf("invalid texture mag filter: 0x%x", o.MagFilter)
+	}
+	switch o.WrapMode {
+	case gl.REPEAT, gl.MIRRORED_REPEAT, gl.CLAMP_TO_EDGE, gl.CLAMP_TO_BORDER:
+	default:
+		return o, fmt.Errorf("invalid texture wrap mode: 0x%x", o.WrapMode)
+	}
+	if o.Anisotropy < 0 {
+		return o, fmt.Errorf("invalid texture anisotropy: %f", o.Anisotropy)
+	}
+	return o, nil
+}
+
+// NewTexture creates a mipmapped texture using
+// LINEAR filtering and CLAMP_TO_EDGE wrapping.
 func NewTexture(filepath string, index int) (*Texture, error) {
-	rgba, err := rgbaFromFile(filepath)
+	return NewTextureWithOptions(filepath, index, TextureOptions{
+		GenerateMipmaps: true,
+		MinFilter:       gl.LINEAR,
+	})
+}
+
+// NewTextureWithOptions .
+func NewTextureWithOptions(filepath string, index int, opts TextureOptions) (*Texture, error) {
+	img, err := decodeImageFile(filepath)
+	if err != nil {
+		return nil, err
+	}
+	return NewTextureFromImage(img, index, opts)
+}
+
+// NewTextureFromImage uploads an image decoded or generated
+// in memory, flipped the same way as NewTextureWithOptions.
+func NewTextureFromImage(img image.Image, index int, opts TextureOptions) (*Texture, error) {
+	opts, err := opts.validate()
 	if err != nil {
 		return nil, err
 	}
 	if index < 0 {
 		return nil, fmt.Errorf("texture target out of bounds: %d != 0 <= x", index)
 	}
+	// checked before flipping, which copies the image, as
+	// drivers otherwise fail with an opaque GL_INVALID_VALUE
+	maxSize := GetGLLimits().MaxTextureSize
+	if size := img.Bounds().Size(); size.X > int(maxSize) || size.Y > int(maxSize) {
+		return nil, fmt.Errorf("texture too large: %dx%d, maximum size is %d", size.X, size.Y, maxSize)
+	}
+	// Replaced manually drawing image.Image into image.RGBA
+	// with disintegration/imaging lib, which provide convenience methods
+	// for flipping/transposing/etc.
+	//
+	rgba := imaging.FlipV(img)
+	//
+	// rgba := image.NewRGBA(img.Bounds())
+	// if rgba.Stride != rgba.Rect.Size().X*4 {
+	// 	return nil, fmt.Errorf("error creating texture rgba: unsupported stride")
+	// }
+	// draw.Draw(rgba, img.Bounds(), img, image.Point{0, 0}, draw.Src)
 
 	var id uint32
 	gl.GenTextures(1, &id)
@@ -38,18 +132,27 @@ func NewTexture(filepath string, index int) (*Texture, error) {
 		id:          id,
 		index:       index,
 		textureUnit: uint32(gl.TEXTURE0 + index),
+		width:       rgba.Rect.Size().X,
+		height:      rgba.Rect.Size().Y,
 	}
 	texture.Bind()
 	defer texture.Unbind()
 
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, opts.MinFilter)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, opts.MagFilter)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, opts.WrapMode)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, opts.WrapMode)
+	if opts.Anisotropy > 1 {
+		setTextureAnisotropy(gl.TEXTURE_2D, opts.Anisotropy)
+	}
+	internalFormat := int32(gl.RGBA8)
+	if opts.SRGB {
+		internalFormat = gl.SRGB8_ALPHA8
+	}
 	gl.TexImage2D(
 		gl.TEXTURE_2D,
 		0,
-		gl.RGBA,
+		internalFormat,
 		int32(rgba.Rect.Size().X),
 		int32(rgba.Rect.Size().Y),
 		0,
@@ -57,7 +160,10 @@ func NewTexture(filepath string, index int) (*Texture, error) {
 		gl.UNSIGNED_BYTE,
 		gl.Ptr(rgba.Pix),
 	)
-	gl.GenerateMipmap(gl.TEXTURE_2D)
+	if opts.GenerateMipmaps {
+		gl.GenerateMipmap(gl.TEXTURE_2D)
+	}
+	track(texture)
 	return texture, nil
 }
 
@@ -75,6 +181,13 @@ func (t *Texture) Unbind() {
 	gl.BindTexture(gl.TEXTURE_2D, 0)
 }
 
+// Delete .
+func (t *Texture) Delete() {
+	untrack(t)
+	gl.DeleteTextures(1, &t.id)
+	t.id = 0
+}
+
 // GetID .
 func (t *Texture) GetID() uint32 {
 	return t.id
@@ -85,33 +198,75 @@ func (t *Texture) GetIndex() int {
 	return t.index
 }
 
+// GetSize returns the dimensions of the texture in pixels.
+func (t *Texture) GetSize() (int, int) {
+	return t.width, t.height
+}
+
 // GetTextureUnit .
 func (t *Texture) GetTextureUnit() uint32 {
 	return t.textureUnit
 }
 
-func rgbaFromFile(filepath string) (*image.NRGBA, error) {
+// BindTextures binds 2D textures, ex: framebuffer attachments, to
+// consecutive texture units starting at GL_TEXTURE0, so that shader
+// samplers can be pointed at them with SetUniform1i(name, i).
+// The active texture unit is reset to GL_TEXTURE0 afterwards.
+func BindTextures(textureIDs ...uint32) error {
+	var maxUnits int32
+	gl.GetIntegerv(gl.MAX_COMBINED_TEXTURE_IMAGE_UNITS, &maxUnits)
+	if len(textureIDs) > int(maxUnits) {
+		return fmt.Errorf("too many textures to bind: %d > %d texture units", len(textureIDs), maxUnits)
+	}
+	for i, textureID := range textureIDs {
+		gl.ActiveTexture(gl.TEXTURE0 + uint32(i))
+		gl.BindTexture(gl.TEXTURE_2D, textureID)
+	}
+	gl.ActiveTexture(gl.TEXTURE0)
+	return nil
+}
+
+// UnbindTextures unbinds the 2D textures of the first count
+// texture units, reverting BindTextures.
+func UnbindTextures(count int) {
+	for i := 0; i < count; i++ {
+		gl.ActiveTexture(gl.TEXTURE0 + uint32(i))
+		gl.BindTexture(gl.TEXTURE_2D, 0)
+	}
+	gl.ActiveTexture(gl.TEXTURE0)
+}
+
+// setTextureAnisotropy sets the anisotropy of the bound texture,
+// clamped to the maximum supported. Anisotropic filtering is core
+// since OpenGL 4.6 but may still be missing from some drivers.
+func setTextureAnisotropy(target uint32, anisotropy float32) {
+	// errors left by earlier calls would otherwise be
+	// mistaken for the query not being supported
+	if err := CheckGLError("before anisotropy query"); err != nil {
+		fmt.Printf("[OpenGL WARNING] %s\n", err)
+	}
+	var maxAnisotropy float32
+	gl.GetFloatv(gl.MAX_TEXTURE_MAX_ANISOTROPY, &maxAnisotropy)
+	if err := CheckGLError("anisotropy query"); err != nil || maxAnisotropy <= 1 {
+		fmt.Printf("[OpenGL WARNING] anisotropic filtering not supported, ignoring anisotropy %.1f\n", anisotropy)
+		return
+	}
+	if anisotropy > maxAnisotropy {
+		anisotropy = maxAnisotropy
+	}
+	gl.TexParameterf(target, gl.TEXTURE_MAX_ANISOTROPY, anisotropy)
+}
+
+func decodeImageFile(filepath string) (image.Image, error) {
 	reader, err := os.Open(filepath)
 	if err != nil {
-		return nil, fmt.Errorf("error reading texture file: %s", err)
+		return nil, fmt.Errorf("error reading texture file %q: %s", filepath, err)
 	}
 	defer reader.Close()
 
 	img, _, err := image.Decode(reader)
 	if err != nil {
-		return nil, fmt.Errorf("error decoding texture file: %s", err)
+		return nil, fmt.Errorf("error decoding texture file %q: %s", filepath, err)
 	}
-	// Replaced manually drawing image.Image into image.RGBA
-	// with disintegration/imaging lib, which provide convenience methods
-	// for flipping/transposing/etc.
-	//
-	nrgba := imaging.FlipV(img)
-	//
-	// rgba := image.NewRGBA(img.Bounds())
-	// if rgba.Stride != rgba.Rect.Size().X*4 {
-	// 	return nil, fmt.Errorf("error creating texture rgba: unsupported stride")
-	// }
-	// draw.Draw(rgba, img.Bounds(), img, image.Point{0, 0}, draw.Src)
-
-	return nrgba, nil
+	return img, nil
 }
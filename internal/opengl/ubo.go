@@ -0,0 +1,92 @@
+package opengl
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// UBO is a uniform buffer object shared by the shader programs
+// whose uniform block is linked to its binding point,
+// see ShaderProgram.SetUniformBlockBinding.
+//
+// Blocks are expected to use `layout(std140)`: scalars are aligned
+// on 4 bytes, vec2 on 8 bytes, vec3 and vec4 on 16 bytes, and each
+// array element and matrix column is padded to 16 bytes. A mat4 is
+// thus 64 contiguous bytes, while a vec3 followed by a float shares
+// 16 bytes. Offsets given to SetData must follow these rules.
+type UBO struct {
+	id      uint32
+	binding uint32
+	size    int
+}
+
+// NewUBO .
+func NewUBO(size int, binding uint32) (*UBO, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid UBO size: %d", size)
+	}
+	var uboID uint32
+	gl.GenBuffers(1, &uboID)
+	ubo := &UBO{id: uboID, binding: binding, size: size}
+
+	ubo.Bind()
+	defer ubo.Unbind()
+
+	gl.BufferData(gl.UNIFORM_BUFFER, ubo.size, nil, gl.DYNAMIC_DRAW)
+	gl.BindBufferBase(gl.UNIFORM_BUFFER, ubo.binding, ubo.id)
+
+	track(ubo)
+	return ubo, nil
+}
+
+// SetData writes data at offset bytes from the start of the buffer.
+func (u *UBO) SetData(offset int, data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return u.setData(offset, len(data), gl.Ptr(data))
+}
+
+// SetFloats writes data at offset bytes from the start of the buffer,
+// ex: a mgl32.Mat4 using `ubo.SetFloats(0, m[:])`.
+func (u *UBO) SetFloats(offset int, data []float32) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return u.setData(offset, 4*len(data), gl.Ptr(data))
+}
+
+func (u *UBO) setData(offset, size int, ptr unsafe.Pointer) error {
+	if offset < 0 || offset+size > u.size {
+		return fmt.Errorf("UBO write out of bounds: [%d, %d) > %d bytes", offset, offset+size, u.size)
+	}
+	u.Bind()
+	defer u.Unbind()
+
+	gl.BufferSubData(gl.UNIFORM_BUFFER, offset, size, ptr)
+	return nil
+}
+
+// GetBinding .
+func (u *UBO) GetBinding() uint32 {
+	return u.binding
+}
+
+// Bind .
+func (u *UBO) Bind() {
+	gl.BindBuffer(gl.UNIFORM_BUFFER, u.id)
+}
+
+// Unbind .
+func (u *UBO) Unbind() {
+	gl.BindBuffer(gl.UNIFORM_BUFFER, 0)
+}
+
+// Delete .
+func (u *UBO) Delete() {
+	untrack(u)
+	gl.DeleteBuffers(1, &u.id)
+	u.id = 0
+}
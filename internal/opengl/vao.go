@@ -6,13 +6,19 @@ import "github.com/go-gl/gl/v4.6-core/gl"
 type VAO struct {
 	id  uint32
 	ibo *IBO
+
+	// next available vertex attribute location,
+	// shared by all VBOs added to this VAO
+	attribIndex uint32
 }
 
 // NewVAO .
 func NewVAO() *VAO {
 	var vaoID uint32
 	gl.GenVertexArrays(1, &vaoID)
-	return &VAO{id: vaoID}
+	vao := &VAO{id: vaoID}
+	track(vao)
+	return vao
 }
 
 // AddVBO .
@@ -25,11 +31,13 @@ func (v *VAO) AddVBO(vbo *VBO) {
 
 	layout := vbo.GetLayout()
 
-	offset := 0
-	for idx, element := range layout.elements {
-		gl.VertexAttribPointer(uint32(idx), element.Count, element.DataType.value, element.Normalized, layout.GetStride(), gl.PtrOffset(offset))
-		gl.EnableVertexAttribArray(uint32(idx))
-		offset += (element.DataType.size * int(element.Count))
+	for _, element := range layout.GetElements() {
+		gl.VertexAttribPointer(v.attribIndex, element.Count, element.DataType.value, element.Normalized, layout.GetStride(), gl.PtrOffset(element.GetOffset()))
+		gl.EnableVertexAttribArray(v.attribIndex)
+		if element.Divisor > 0 {
+			gl.VertexAttribDivisor(v.attribIndex, element.Divisor)
+		}
+		v.attribIndex++
 	}
 }
 
@@ -57,3 +65,11 @@ func (v *VAO) Bind() {
 func (v *VAO) Unbind() {
 	gl.BindVertexArray(0)
 }
+
+// Delete does not delete the VBOs and IBO
+// attached to the VAO.
+func (v *VAO) Delete() {
+	untrack(v)
+	gl.DeleteVertexArrays(1, &v.id)
+	v.id = 0
+}
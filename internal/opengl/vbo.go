@@ -1,6 +1,7 @@
 package opengl
 
 import (
+	"fmt"
 	"unsafe"
 
 	"github.com/go-gl/gl/v4.6-core/gl"
@@ -36,28 +37,83 @@ type GLDataType struct {
 type VBO struct {
 	id     uint32
 	layout *VBOLayout
+
+	// allocated size in bytes
+	size  int
+	usage uint32
 }
 
 // NewVBO .
 func NewVBO(size int) (*VBO, error) {
 	var vboID uint32
 	gl.GenBuffers(1, &vboID)
-	vbo := &VBO{id: vboID}
+	vbo := &VBO{id: vboID, size: size, usage: gl.DYNAMIC_DRAW}
+
+	vbo.Bind()
+	defer vbo.Unbind()
+
+	gl.BufferData(gl.ARRAY_BUFFER, size, gl.Ptr(nil), vbo.usage)
+
+	track(vbo)
+	return vbo, nil
+}
+
+// NewStaticVBO creates a VBO initialized with vertices
+// that are not expected to change.
+func NewStaticVBO(vertices []float32) (*VBO, error) {
+	if len(vertices) == 0 {
+		return nil, fmt.Errorf("static VBO requires at least one vertex")
+	}
+	var vboID uint32
+	gl.GenBuffers(1, &vboID)
+	vbo := &VBO{id: vboID, size: 4 * len(vertices), usage: gl.STATIC_DRAW}
 
 	vbo.Bind()
 	defer vbo.Unbind()
 
-	gl.BufferData(gl.ARRAY_BUFFER, size, gl.Ptr(nil), gl.DYNAMIC_DRAW)
+	gl.BufferData(gl.ARRAY_BUFFER, vbo.size, gl.Ptr(vertices), vbo.usage)
 
+	track(vbo)
 	return vbo, nil
 }
 
 // SetData .
 func (v *VBO) SetData(data VBOData) {
+	v.setData(data.GetVBOSize(), data.GetVBOGLPtr())
+}
+
+// SetVertices .
+func (v *VBO) SetVertices(vertices []float32) {
+	if len(vertices) == 0 {
+		return
+	}
+	v.setData(4*len(vertices), gl.Ptr(vertices))
+}
+
+// GetSize returns the allocated size in bytes.
+func (v *VBO) GetSize() int {
+	return v.size
+}
+
+// setData reallocates the buffer when data does not fit,
+// otherwise it is updated in place.
+func (v *VBO) setData(size int, ptr unsafe.Pointer) {
 	v.Bind()
 	defer v.Unbind()
 
-	gl.BufferSubData(gl.ARRAY_BUFFER, 0, data.GetVBOSize(), data.GetVBOGLPtr())
+	if needsRealloc(v.size, size) {
+		v.size = size
+		gl.BufferData(gl.ARRAY_BUFFER, v.size, ptr, v.usage)
+		return
+	}
+	gl.BufferSubData(gl.ARRAY_BUFFER, 0, size, ptr)
+}
+
+// needsRealloc reports whether n bytes or elements do not fit in a
+// buffer of the given capacity, in which case it must be reallocated
+// with BufferData rather than updated with BufferSubData.
+func needsRealloc(capacity, n int) bool {
+	return n > capacity
 }
 
 // GetLayout .
@@ -80,6 +136,13 @@ func (v *VBO) Unbind() {
 	gl.BindBuffer(gl.ARRAY_BUFFER, 0)
 }
 
+// Delete .
+func (v *VBO) Delete() {
+	untrack(v)
+	gl.DeleteBuffers(1, &v.id)
+	v.id = 0
+}
+
 // VBOLayout .
 type VBOLayout struct {
 	stride   int32
@@ -90,8 +153,9 @@ type VBOLayout struct {
 func NewVBOLayout(elements ...VBOLayoutElement) *VBOLayout {
 	layout := &VBOLayout{}
 	for _, e := range elements {
+		e.offset = int(layout.stride)
 		layout.elements = append(layout.elements, e)
-		layout.stride += (int32(e.DataType.size) * e.Count)
+		layout.stride += e.GetSize()
 	}
 	return layout
 }
@@ -101,11 +165,32 @@ func (l *VBOLayout) GetStride() int32 {
 	return l.stride
 }
 
+// GetElements .
+func (l *VBOLayout) GetElements() []VBOLayoutElement {
+	return l.elements
+}
+
 // VBOLayoutElement .
 type VBOLayoutElement struct {
 	Count      int32
 	Normalized bool
 	DataType   GLDataType
+	// Divisor is the number of instances drawn before the
+	// attribute advances, 0 advances it per vertex.
+	Divisor uint32
+
+	offset int
+}
+
+// GetSize returns the size in bytes of the element.
+func (e VBOLayoutElement) GetSize() int32 {
+	return int32(e.DataType.size) * e.Count
+}
+
+// GetOffset returns the offset in bytes of the element
+// from the start of a vertex. It is computed by NewVBOLayout.
+func (e VBOLayoutElement) GetOffset() int {
+	return e.offset
 }
 
 // VBOData .
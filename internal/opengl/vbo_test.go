@@ -0,0 +1,22 @@
+package opengl
+
+import "testing"
+
+func TestNeedsRealloc(t *testing.T) {
+	cases := []struct {
+		name     string
+		capacity int
+		n        int
+		want     bool
+	}{
+		{"grow", 64, 128, true},
+		{"same size", 64, 64, false},
+		{"shrink", 64, 16, false},
+		{"empty buffer", 0, 4, true},
+	}
+	for _, c := range cases {
+		if got := needsRealloc(c.capacity, c.n); got != c.want {
+			t.Errorf("%s: needsRealloc(%d, %d) = %v, want %v", c.name, c.capacity, c.n, got, c.want)
+		}
+	}
+}